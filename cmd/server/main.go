@@ -16,6 +16,7 @@ import (
 	"github.com/michael/flowreader/internal/handler"
 	"github.com/michael/flowreader/internal/repository"
 	"github.com/michael/flowreader/internal/service"
+	"github.com/michael/flowreader/internal/utils"
 	"github.com/michael/flowreader/internal/worker"
 	"github.com/michael/flowreader/internal/ws"
 )
@@ -42,34 +43,101 @@ func main() {
 	sessionRepo := repository.NewSessionRepository(pool)
 	feedRepo := repository.NewFeedRepository(pool)
 	articleRepo := repository.NewArticleRepository(pool)
+	iconRepo := repository.NewFeedIconRepository(pool)
+	tagRepo := repository.NewTagRepository(pool)
+	highlightRepo := repository.NewHighlightRepository(pool)
+	readLaterRepo := repository.NewReadLaterRepository(pool)
+	readingRepo := repository.NewReadingRepository(pool)
+	savedSearchRepo := repository.NewSavedSearchRepository(pool)
+	quickFindRepo := repository.NewQuickFindRepository(pool)
+	retentionRepo := repository.NewRetentionRepository(pool)
+	archiveRepo := repository.NewArchiveRepository(pool)
+	editionRepo := repository.NewDailyEditionRepository(pool)
 
 	// Initialize services
 	authService := service.NewAuthService(userRepo, sessionRepo)
 	feedService := service.NewFeedService(feedRepo)
 	aiService := service.NewAIService()
+	tagService := service.NewTagService(tagRepo)
+	highlightService := service.NewHighlightService(highlightRepo, articleRepo, feedRepo)
+	readingService := service.NewReadingService(readingRepo, articleRepo, feedRepo)
+	savedSearchService := service.NewSavedSearchService(savedSearchRepo, articleRepo)
+	quickFindService := service.NewQuickFindService(quickFindRepo)
+	retentionService := service.NewRetentionService(retentionRepo, feedRepo)
+
+	imageCache, err := utils.NewDiskCache(cfg.ImageCacheDir, int64(cfg.ImageCacheMaxMB)<<20)
+	if err != nil {
+		log.Printf("Image cache disabled: %v", err)
+		imageCache = nil
+	}
+	if cfg.ImageProxySecret == "" {
+		log.Println("IMAGE_PROXY_SECRET not set, proxied image URLs will not survive restarts")
+	}
+	imageProxy := service.NewImageProxyService(utils.NewURLSigner(cfg.ImageProxySecret), imageCache, int64(cfg.ImageMaxBytes))
 
 	// Initialize WS Hub
 	hub := ws.NewHub()
 	go hub.Run()
 
-	fetchService := service.NewFetchService(feedRepo, articleRepo, hub)
+	faviconService := service.NewFaviconService(iconRepo)
+	sanitizer := utils.NewContentSanitizer(cfg.EmbedAllowedOrigins...)
+	readerService := service.NewReaderService(articleRepo, sanitizer)
+	exportService := service.NewExportService(articleRepo, highlightRepo, sanitizer)
+	trackingFilter := utils.NewTrackingFilter(utils.TrackingRules{
+		Params:      cfg.TrackingParams,
+		Redirectors: cfg.TrackingRedirectors,
+		PixelHosts:  cfg.TrackingPixelHosts,
+	})
+	fetchService := service.NewFetchService(feedRepo, articleRepo, faviconService, trackingFilter, sanitizer, hub)
+	archiveService := service.NewArchiveService(archiveRepo, articleRepo, feedRepo, readerService, imageProxy)
+	epubService := service.NewEPUBService(articleRepo, readLaterRepo, editionRepo, savedSearchService, imageProxy)
 
 	// Initialize handlers
 	authHandler := handler.NewAuthHandler(authService)
-	feedHandler := handler.NewFeedHandler(feedService, fetchService, authService)
-	articleHandler := handler.NewArticleHandler(articleRepo, feedService, authService, aiService, hub)
+	feedHandler := handler.NewFeedHandler(feedService, fetchService, faviconService, authService)
+	articleHandler := handler.NewArticleHandler(articleRepo, feedService, authService, aiService, readerService, tagService, readLaterRepo, savedSearchService, sanitizer, imageProxy, hub)
 	wsHandler := handler.NewWSHandler(hub, authService)
 	adminHandler := handler.NewAdminHandler(userRepo, authService)
+	proxyHandler := handler.NewProxyHandler(imageProxy)
+	tagHandler := handler.NewTagHandler(tagService, authService)
+	highlightHandler := handler.NewHighlightHandler(highlightService, authService)
+	readingHandler := handler.NewReadingHandler(readingService, authService)
+	savedSearchHandler := handler.NewSavedSearchHandler(savedSearchService, authService)
+	sidebarHandler := handler.NewSidebarHandler(feedService, tagService, savedSearchService, authService)
+	quickFindHandler := handler.NewQuickFindHandler(quickFindService, authService)
+	retentionHandler := handler.NewRetentionHandler(retentionService, authService)
+	archiveHandler := handler.NewArchiveHandler(archiveService, authService)
+	exportHandler := handler.NewExportHandler(exportService, epubService, authService)
 
 	// Start background workers
 	fetcher := worker.NewFeedFetcher(fetchService, 15*time.Minute, 5)
 	fetcher.Start()
 	defer fetcher.Stop()
 
-	cleaner := worker.NewCleaner(articleRepo, 24*time.Hour)
+	cleaner := worker.NewCleaner(retentionRepo, 24*time.Hour)
 	cleaner.Start()
 	defer cleaner.Stop()
 
+	resanitizer := worker.NewResanitizer(articleRepo, sanitizer, 200)
+	resanitizer.Start()
+	defer resanitizer.Stop()
+
+	languageBackfill := worker.NewLanguageBackfill(articleRepo, feedRepo, 200)
+	languageBackfill.Start()
+	defer languageBackfill.Stop()
+
+	archiver := worker.NewArchiver(archiveService, time.Minute, 10)
+	archiver.Start()
+	defer archiver.Stop()
+
+	snoozeWaker := worker.NewSnoozeWaker(readLaterRepo, hub, time.Minute)
+	snoozeWaker.Start()
+	defer snoozeWaker.Stop()
+
+	editionGenerator := worker.NewEditionGenerator(epubService, 15*time.Minute)
+	editionGenerator.Start()
+	defer editionGenerator.Stop()
+
 	// Initialize router
 	r := chi.NewRouter()
 
@@ -78,7 +146,6 @@ func main() {
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
-	r.Use(middleware.Timeout(30 * time.Second))
 
 	// Baseline security headers (defense in depth).
 	r.Use(func(next http.Handler) http.Handler {
@@ -91,8 +158,12 @@ func main() {
 		})
 	})
 
+	// Requests time out after 30 seconds, except the long-running and
+	// streamed API routes, which handle their own deadlines
+	defaultTimeout := middleware.Timeout(30 * time.Second)
+
 	// Health check endpoint
-	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
+	r.With(defaultTimeout).Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		if err := pool.Ping(r.Context()); err != nil {
 			http.Error(w, "Database connection failed", http.StatusServiceUnavailable)
 			return
@@ -104,13 +175,14 @@ func main() {
 
 	// API routes
 	r.Route("/api/v1", func(r chi.Router) {
-		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		r.With(defaultTimeout).Get("/", func(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("Content-Type", "application/json")
 			w.Write([]byte(`{"message":"FlowReader API v1"}`))
 		})
 
 		// Auth routes (public) — rate-limited to mitigate brute-force attacks.
 		r.Route("/auth", func(r chi.Router) {
+			r.Use(defaultTimeout)
 			r.Use(handler.NewAuthRateLimiter())
 			r.Post("/register", authHandler.Register)
 			r.Post("/login", authHandler.Login)
@@ -119,44 +191,147 @@ func main() {
 
 		// User routes
 		r.Route("/users", func(r chi.Router) {
+			r.Use(defaultTimeout)
 			r.Get("/me", authHandler.Me)
+			r.Patch("/me/preferences", authHandler.UpdatePreferences)
 		})
 
 		// Feed routes
 		r.Route("/feeds", func(r chi.Router) {
+			r.Use(defaultTimeout)
 			r.Get("/", feedHandler.List)
 			r.Post("/", feedHandler.Add)
 			r.Post("/refresh", feedHandler.Refresh)
 			r.Post("/import/opml", feedHandler.ImportOPML)
 			r.Get("/export/opml", feedHandler.ExportOPML)
 			r.Get("/{id}", feedHandler.Get)
+			r.Get("/{id}/icon", feedHandler.Icon)
 			r.Patch("/{id}", feedHandler.Update)
 			r.Delete("/{id}", feedHandler.Delete)
 			r.Get("/{id}/articles", articleHandler.ListByFeed)
 			r.Post("/{id}/read-all", articleHandler.MarkAllRead)
+			r.Get("/{id}/retention", retentionHandler.GetFeed)
+			r.Put("/{id}/retention", retentionHandler.SetFeed)
+			r.Delete("/{id}/retention", retentionHandler.DeleteFeed)
 		})
 
 		// Article routes
 		r.Route("/articles", func(r chi.Router) {
-			r.Get("/", articleHandler.List)
-			r.Get("/search", articleHandler.Search)
-			r.Post("/read-all", articleHandler.MarkAllReadGlobal)
-			r.Get("/favorites", articleHandler.GetFavorites)
-			r.Get("/{id}", articleHandler.Get)
-			r.Post("/{id}/read", articleHandler.MarkRead)
-			r.Delete("/{id}/read", articleHandler.MarkUnread)
-			r.Post("/{id}/favorite", articleHandler.ToggleFavorite)
-			r.Post("/{id}/summarize", articleHandler.Summarize)
+			// Archiving fetches the page and its images under its own deadline
+			r.Post("/{id}/archive", archiveHandler.Create)
+
+			r.Group(func(r chi.Router) {
+				r.Use(defaultTimeout)
+				r.Get("/", articleHandler.List)
+				r.Get("/search", articleHandler.Search)
+				r.Post("/read-all", articleHandler.MarkAllReadGlobal)
+				r.Post("/batch", articleHandler.Batch)
+				r.Get("/favorites", articleHandler.GetFavorites)
+				r.Get("/recent", articleHandler.Recent)
+				r.Get("/{id}", articleHandler.Get)
+				r.Post("/{id}/read", articleHandler.MarkRead)
+				r.Delete("/{id}/read", articleHandler.MarkUnread)
+				r.Post("/{id}/favorite", articleHandler.ToggleFavorite)
+				r.Put("/{id}/favorite", articleHandler.Favorite)
+				r.Delete("/{id}/favorite", articleHandler.Unfavorite)
+				r.Post("/{id}/summarize", articleHandler.Summarize)
+				r.Get("/{id}/fulltext", articleHandler.FullText)
+				r.Put("/{id}/tags", articleHandler.SetTags)
+				r.Get("/{id}/highlights", highlightHandler.ListForArticle)
+				r.Post("/{id}/highlights", highlightHandler.Create)
+				r.Put("/{id}/read-later", articleHandler.AddReadLater)
+				r.Delete("/{id}/read-later", articleHandler.RemoveReadLater)
+				r.Post("/{id}/open", readingHandler.RecordOpen)
+				r.Get("/{id}/archive", archiveHandler.Get)
+				r.Delete("/{id}/archive", archiveHandler.Delete)
+			})
+		})
+
+		// Read-later queue routes
+		r.Route("/read-later", func(r chi.Router) {
+			r.Use(defaultTimeout)
+			r.Get("/", articleHandler.ListReadLater)
+			r.Post("/reorder", articleHandler.ReorderReadLater)
+		})
+
+		// Saved search (smart folder) routes
+		r.Route("/saved-searches", func(r chi.Router) {
+			r.Use(defaultTimeout)
+			r.Get("/", savedSearchHandler.List)
+			r.Post("/", savedSearchHandler.Create)
+			r.Patch("/{id}", savedSearchHandler.Update)
+			r.Delete("/{id}", savedSearchHandler.Delete)
+		})
+
+		r.With(defaultTimeout).Get("/sidebar", sidebarHandler.Get)
+		r.With(defaultTimeout).Get("/quickfind", quickFindHandler.Find)
+
+		// Export routes
+		r.Route("/export", func(r chi.Router) {
+			// Streamed exports have no deadline: they end with the client
+			r.Get("/articles", exportHandler.Articles)
+			r.Post("/epub", exportHandler.EPUB)
+
+			r.Group(func(r chi.Router) {
+				r.Use(defaultTimeout)
+				r.Get("/epub/daily", exportHandler.GetEdition)
+				r.Put("/epub/daily", exportHandler.SetEdition)
+				r.Delete("/epub/daily", exportHandler.DeleteEdition)
+				r.Post("/epub/daily/generate", exportHandler.GenerateEdition)
+				r.Get("/epub/daily/download", exportHandler.DownloadEdition)
+			})
+		})
+
+		// Retention policy routes
+		r.Route("/retention", func(r chi.Router) {
+			r.Use(defaultTimeout)
+			r.Get("/", retentionHandler.Get)
+			r.Put("/", retentionHandler.SetUser)
+			r.Delete("/", retentionHandler.DeleteUser)
+			r.Get("/dry-run", retentionHandler.DryRun)
+		})
+
+		// Reading statistics routes
+		r.Route("/stats", func(r chi.Router) {
+			r.Use(defaultTimeout)
+			r.Get("/reading", readingHandler.Stats)
+		})
+
+		// Highlight routes
+		r.Route("/highlights", func(r chi.Router) {
+			r.Use(defaultTimeout)
+			r.Get("/", highlightHandler.List)
+			r.Patch("/{id}", highlightHandler.Update)
+			r.Delete("/{id}", highlightHandler.Delete)
+		})
+
+		// Tag routes
+		r.Route("/tags", func(r chi.Router) {
+			r.Use(defaultTimeout)
+			r.Get("/", tagHandler.List)
+			r.Post("/", tagHandler.Create)
+			r.Patch("/{id}", tagHandler.Update)
+			r.Delete("/{id}", tagHandler.Delete)
+		})
+
+		// Proxy routes (signed URLs, no session required)
+		r.Route("/proxy", func(r chi.Router) {
+			r.Use(defaultTimeout)
+			r.Get("/image", proxyHandler.Image)
 		})
 
 		// WebSocket route
-		r.Get("/ws", wsHandler.Connect)
+		r.With(defaultTimeout).Get("/ws", wsHandler.Connect)
 
 		// Admin routes
 		r.Route("/admin", func(r chi.Router) {
+			r.Use(defaultTimeout)
 			r.Use(adminHandler.AdminOnly)
 			r.Get("/users", adminHandler.ListUsers)
 			r.Delete("/users/{id}", adminHandler.DeleteUser)
+			r.Get("/retention", retentionHandler.GetInstance)
+			r.Put("/retention", retentionHandler.SetInstance)
+			r.Get("/retention/dry-run", retentionHandler.DryRunInstance)
 		})
 	})
 
@@ -164,7 +339,7 @@ func main() {
 	staticPath := "./web/dist"
 	if _, err := os.Stat(staticPath); err == nil {
 		fs := http.FileServer(http.Dir(staticPath))
-		r.Handle("/*", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.With(defaultTimeout).Handle("/*", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// If the file exists, serve it, otherwise serve index.html (for SPA routing)
 			path := staticPath + r.URL.Path
 			if _, err := os.Stat(path); os.IsNotExist(err) {
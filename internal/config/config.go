@@ -4,6 +4,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 )
 
 // Config holds all application configuration.
@@ -11,6 +12,20 @@ type Config struct {
 	Port        string
 	DatabaseURL string
 	Environment string
+
+	// Image proxy settings
+	ImageProxySecret string
+	ImageCacheDir    string
+	ImageCacheMaxMB  int
+	ImageMaxBytes    int
+
+	// Tracking filter rules (added to the built-in defaults)
+	TrackingParams      []string
+	TrackingRedirectors []string
+	TrackingPixelHosts  []string
+
+	// Extra iframe origins allowed in article content (added to the defaults)
+	EmbedAllowedOrigins []string
 }
 
 // Load reads configuration from environment variables with sensible defaults.
@@ -19,6 +34,17 @@ func Load() *Config {
 		Port:        getEnv("PORT", "8080"),
 		DatabaseURL: getEnv("DATABASE_URL", "postgres://flowreader:flowreader@db:5432/flowreader?sslmode=disable"),
 		Environment: getEnv("ENV", "development"),
+
+		ImageProxySecret: getEnv("IMAGE_PROXY_SECRET", ""),
+		ImageCacheDir:    getEnv("IMAGE_CACHE_DIR", "./data/images"),
+		ImageCacheMaxMB:  getEnvInt("IMAGE_CACHE_MAX_MB", 256),
+		ImageMaxBytes:    getEnvInt("IMAGE_MAX_BYTES", 10<<20),
+
+		TrackingParams:      getEnvList("TRACKING_PARAMS"),
+		TrackingRedirectors: getEnvList("TRACKING_REDIRECTORS"),
+		TrackingPixelHosts:  getEnvList("TRACKING_PIXEL_HOSTS"),
+
+		EmbedAllowedOrigins: getEnvList("EMBED_ALLOWED_ORIGINS"),
 	}
 }
 
@@ -39,3 +65,14 @@ func getEnvInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+// getEnvList returns a comma-separated environment variable as a slice.
+func getEnvList(key string) []string {
+	var values []string
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
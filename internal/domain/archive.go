@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArchiveSource tells where the archived content came from.
+type ArchiveSource string
+
+// Archive sources
+const (
+	// ArchiveSourceExtracted is the full page extracted from the article URL.
+	ArchiveSourceExtracted ArchiveSource = "extracted"
+	// ArchiveSourceFeed is the feed's copy, used when extraction fails.
+	ArchiveSourceFeed ArchiveSource = "feed"
+)
+
+// ArticleArchive is a permanent, self-contained snapshot of an article: its
+// sanitized content with the images inlined as data URIs, so that it stays
+// readable after the original page disappears. Archived articles are never
+// deleted by the cleaner.
+type ArticleArchive struct {
+	ArticleID        uuid.UUID     `json:"article_id"`
+	UserID           uuid.UUID     `json:"-"`
+	Title            string        `json:"title"`
+	URL              string        `json:"url,omitempty"`
+	Author           string        `json:"author,omitempty"`
+	PublishedAt      *time.Time    `json:"published_at,omitempty"`
+	Content          string        `json:"content"`
+	Source           ArchiveSource `json:"source"`
+	ImageCount       int           `json:"image_count"`
+	FailedImageCount int           `json:"failed_image_count"`
+	SizeBytes        int64         `json:"size_bytes"`
+	ArchivedAt       time.Time     `json:"archived_at"`
+}
+
+// ArchiveRepository defines the interface for article archive access.
+type ArchiveRepository interface {
+	Save(archive *ArticleArchive) error
+	Get(articleID uuid.UUID) (*ArticleArchive, error)
+	Delete(articleID uuid.UUID) (bool, error)
+	PendingFavorites(limit int) ([]uuid.UUID, error)
+}
@@ -22,27 +22,234 @@ type Article struct {
 	IsRead      bool       `json:"is_read"`
 	IsFavorite  bool       `json:"is_favorite"`
 	ReadAt      *time.Time `json:"read_at,omitempty"`
+	FavoritedAt *time.Time `json:"favorited_at,omitempty"`
 	CreatedAt   time.Time  `json:"created_at"`
 
+	// Language is the detected language code ("en", "fr", ...), "und" when
+	// undetermined. It selects the full-text search configuration.
+	Language string `json:"language,omitempty"`
+
+	// SanitizerVersion is the sanitizer policy version the stored HTML was
+	// cleaned with (0 = never sanitized).
+	SanitizerVersion int `json:"-"`
+
+	// SourceGUID and SourceURL are the GUID and link as published by the
+	// feed, before tracking parameters were stripped. They are not stored:
+	// ingestion matches them against articles stored before canonicalization.
+	SourceGUID string `json:"-"`
+	SourceURL  string `json:"-"`
+
+	// RawContent and RawSummary are the HTML as received, before
+	// sanitization, kept to re-sanitize when the policy changes. Only set at
+	// ingest.
+	RawContent string `json:"-"`
+	RawSummary string `json:"-"`
+
 	// Virtual fields (from joins)
-	FeedTitle string `json:"feed_title,omitempty"`
+	FeedTitle string   `json:"feed_title,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	ReadLater bool     `json:"read_later,omitempty"`
+
+	// Rank is the full-text search rank, only set on search results.
+	Rank float32 `json:"rank,omitempty"`
+}
+
+// SearchResult is the lightweight form of a full-text search hit: article
+// metadata plus highlighted title and body snippet, without the bodies.
+// TitleHighlight and Snippet are HTML-escaped text where the matched terms
+// are wrapped in <mark> elements.
+type SearchResult struct {
+	ID             uuid.UUID  `json:"id"`
+	FeedID         uuid.UUID  `json:"feed_id"`
+	FeedTitle      string     `json:"feed_title,omitempty"`
+	Title          string     `json:"title"`
+	TitleHighlight string     `json:"title_highlight"`
+	Snippet        string     `json:"snippet"`
+	URL            string     `json:"url,omitempty"`
+	Author         string     `json:"author,omitempty"`
+	ImageURL       string     `json:"image_url,omitempty"`
+	PublishedAt    *time.Time `json:"published_at,omitempty"`
+	IsRead         bool       `json:"is_read"`
+	IsFavorite     bool       `json:"is_favorite"`
+	ReadLater      bool       `json:"read_later,omitempty"`
+	Tags           []string   `json:"tags,omitempty"`
+	Rank           float32    `json:"rank"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// Cursor returns the cursor positioned right after the search result.
+func (r *SearchResult) Cursor() *ArticleCursor {
+	rank := r.Rank
+	return &ArticleCursor{
+		PublishedAt: r.PublishedAt,
+		CreatedAt:   r.CreatedAt,
+		ID:          r.ID,
+		Rank:        &rank,
+	}
+}
+
+// ArticleCursor marks a position in an article list ordered by
+// (published_at, created_at, id) descending, articles without a publication
+// date last. Search results are ordered by rank first.
+type ArticleCursor struct {
+	PublishedAt *time.Time `json:"p,omitempty"`
+	CreatedAt   time.Time  `json:"c"`
+	ID          uuid.UUID  `json:"i"`
+	Rank        *float32   `json:"r,omitempty"`
+}
+
+// CursorAfter returns the cursor positioned right after the article. The
+// rank is included for search results.
+func CursorAfter(a *Article, ranked bool) *ArticleCursor {
+	c := &ArticleCursor{
+		PublishedAt: a.PublishedAt,
+		CreatedAt:   a.CreatedAt,
+		ID:          a.ID,
+	}
+	if ranked {
+		rank := a.Rank
+		c.Rank = &rank
+	}
+	return c
+}
+
+// ArticleSort is the ordering of an article list.
+type ArticleSort string
+
+// Article list orderings.
+const (
+	SortNewest    ArticleSort = "newest"
+	SortOldest    ArticleSort = "oldest"
+	SortRelevance ArticleSort = "relevance"
+)
+
+// ArticleFilter selects articles. Zero-valued fields do not filter; all set
+// fields are combined with AND.
+type ArticleFilter struct {
+	UserID          uuid.UUID
+	IDs             []uuid.UUID
+	FeedIDs         []uuid.UUID
+	Category        string
+	IsRead          *bool
+	IsFavorite      *bool
+	Author          string
+	Tags            []string
+	PublishedAfter  *time.Time
+	PublishedBefore *time.Time
+	HasImage        *bool
+	Query           string
+
+	// Fuzzy also matches the query against article titles by
+	// trigram similarity, tolerating typos and partial words. The rank then
+	// adds the title similarity to the full-text rank.
+	Fuzzy bool
+
+	// Search operators: feed titles and authors match case-insensitive
+	// substrings, any of the listed values; excluded values match none.
+	FeedTitles        []string
+	ExcludeFeedTitles []string
+	Authors           []string
+	ExcludeAuthors    []string
+	ExcludeTags       []string
+
+	// Languages are the languages the full-text query is expanded over. When
+	// empty, the languages of the user's feeds are used.
+	Languages []string
+
+	// CreatedBefore bounds the filter by ingest time, so that bulk
+	// operations skip articles fetched after the client loaded its list.
+	CreatedBefore *time.Time
+
+	Sort ArticleSort
+}
+
+// Page selects a slice of an article list. When After is set the list
+// resumes after that cursor and Offset is ignored.
+type Page struct {
+	Limit  int
+	Offset int
+	After  *ArticleCursor
+}
+
+// ArticleFullText is the cached, sanitized full-page content of an article,
+// used by the reader view when the feed only carries an excerpt, with the
+// page metadata found by the extraction.
+type ArticleFullText struct {
+	ArticleID uuid.UUID `json:"article_id"`
+	Title     string    `json:"title,omitempty"`
+	Byline    string    `json:"byline,omitempty"`
+	SiteName  string    `json:"site_name,omitempty"`
+	LeadImage string    `json:"lead_image,omitempty"`
+	Content   string    `json:"content"`
+	FetchedAt time.Time `json:"fetched_at"`
+
+	// Text is the plain-text rendition of Content, derived on read.
+	Text string `json:"text"`
+
+	// RawContent is the extracted HTML before sanitization.
+	RawContent string `json:"-"`
 }
 
+// ArticleHTML holds the HTML fields of an article, used when re-sanitizing
+// rows after a policy change: the HTML as received when it was kept, the
+// stored sanitized HTML otherwise.
+type ArticleHTML struct {
+	ID          uuid.UUID
+	Content     string
+	Summary     string
+	FullContent string
+}
+
+// ArticleText holds the text of an article, used when detecting the language
+// of rows ingested before language detection existed.
+type ArticleText struct {
+	ID       uuid.UUID
+	FeedID   uuid.UUID
+	Title    string
+	Summary  string
+	Content  string
+	Language string
+}
+
+// ArticleBatchOp is a state change applied to many articles at once.
+type ArticleBatchOp string
+
+// Batch operations.
+const (
+	BatchRead       ArticleBatchOp = "read"
+	BatchUnread     ArticleBatchOp = "unread"
+	BatchFavorite   ArticleBatchOp = "favorite"
+	BatchUnfavorite ArticleBatchOp = "unfavorite"
+	BatchTag        ArticleBatchOp = "tag"
+	BatchUntag      ArticleBatchOp = "untag"
+)
+
 // ArticleRepository defines the interface for article data access.
 type ArticleRepository interface {
 	Create(article *Article) error
 	CreateBatch(articles []*Article) error
 	GetByID(id uuid.UUID) (*Article, error)
-	GetByFeedID(feedID uuid.UUID, limit, offset int) ([]*Article, error)
-	GetByUserID(userID uuid.UUID, limit, offset int, unreadOnly bool) ([]*Article, error)
+	GetByFeedID(feedID uuid.UUID, page Page) ([]*Article, error)
+	GetByUserID(userID uuid.UUID, page Page, unreadOnly bool) ([]*Article, error)
 	GetByGUID(feedID uuid.UUID, guid string) (*Article, error)
 	MarkAsRead(id uuid.UUID) error
 	MarkAsUnread(id uuid.UUID) error
 	MarkAllAsRead(feedID uuid.UUID) error
 	MarkAllAsReadGlobal(userID uuid.UUID) error
-	ToggleFavorite(id uuid.UUID) error
-	GetFavorites(userID uuid.UUID, limit, offset int) ([]*Article, error)
+	ToggleFavorite(id uuid.UUID) (bool, *time.Time, error)
+	SetFavorite(id uuid.UUID, favorite bool) (bool, *time.Time, error)
+	GetFavorites(userID uuid.UUID, page Page) ([]*Article, error)
 	CountUnread(feedID uuid.UUID) (int, error)
-	Search(userID uuid.UUID, query string, limit, offset int) ([]*Article, error)
+	Search(userID uuid.UUID, query string, page Page) ([]*Article, error)
+	Query(filter ArticleFilter, page Page) ([]*Article, error)
+	SearchResults(filter ArticleFilter, page Page) ([]*SearchResult, error)
+	Count(filter ArticleFilter) (int, error)
+	BatchUpdate(userID uuid.UUID, ids []uuid.UUID, op ArticleBatchOp) ([]uuid.UUID, error)
+	MarkAsReadMatching(filter ArticleFilter) (int64, error)
+	UnreadCounts(userID uuid.UUID) (map[uuid.UUID]int, error)
+	RecentlyRead(userID uuid.UUID, limit, offset int) ([]*Article, error)
+	CountUnreadMatching(userID uuid.UUID, filters []ArticleFilter) ([]int, error)
 	UpdateAISummary(id uuid.UUID, summary string) error
+	GetFullText(id uuid.UUID) (*ArticleFullText, error)
+	UpdateFullText(fullText *ArticleFullText) error
 }
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EditionSource selects the articles of an EPUB edition.
+type EditionSource string
+
+// Edition sources
+const (
+	// EditionSourceArticles is an explicit list of articles, for one-off
+	// exports only.
+	EditionSourceArticles EditionSource = "articles"
+	// EditionSourceUnread is the unread articles, optionally of one category.
+	EditionSourceUnread EditionSource = "unread"
+	// EditionSourceSavedSearch is the unread articles matching a saved search.
+	EditionSourceSavedSearch EditionSource = "saved_search"
+	// EditionSourceReadLater is the read-later queue, in queue order.
+	EditionSourceReadLater EditionSource = "read_later"
+)
+
+// DailyEdition is a user's scheduled EPUB edition: its settings and the
+// download slot holding the latest generated file. The edition is generated
+// once a day, at the first check after Hour in the user's time zone.
+type DailyEdition struct {
+	UserID        uuid.UUID     `json:"-"`
+	Enabled       bool          `json:"enabled"`
+	Source        EditionSource `json:"source"`
+	Category      string        `json:"category,omitempty"`
+	SavedSearchID *uuid.UUID    `json:"saved_search_id,omitempty"`
+	MaxArticles   int           `json:"max_articles"`
+	Hour          int           `json:"hour"`
+	Timezone      string        `json:"timezone"`
+
+	// Latest edition; ArticleCount is 0 and the slot empty when nothing was
+	// selected on that day.
+	ArticleCount int        `json:"article_count"`
+	SizeBytes    int64      `json:"size_bytes"`
+	GeneratedAt  *time.Time `json:"generated_at,omitempty"`
+	AttemptedAt  *time.Time `json:"attempted_at,omitempty"`
+	LastError    string     `json:"last_error,omitempty"`
+
+	// Virtual field: an edition is queued or being generated
+	Generating bool `json:"generating"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DailyEditionRepository defines the interface for daily edition access.
+type DailyEditionRepository interface {
+	Get(userID uuid.UUID) (*DailyEdition, error)
+	Save(edition *DailyEdition) error
+	Delete(userID uuid.UUID) (bool, error)
+	ListEnabled() ([]*DailyEdition, error)
+	StoreFile(userID uuid.UUID, data []byte, articleCount int, generatedAt time.Time) error
+	StoreFailure(userID uuid.UUID, message string, attemptedAt time.Time) error
+	GetFile(userID uuid.UUID) ([]byte, error)
+}
@@ -15,6 +15,7 @@ type Feed struct {
 	Description   string     `json:"description,omitempty"`
 	SiteURL       string     `json:"site_url,omitempty"`
 	ImageURL      string     `json:"image_url,omitempty"`
+	Category      string     `json:"category,omitempty"`
 	LastFetchedAt *time.Time `json:"last_fetched_at,omitempty"`
 	FetchError    string     `json:"fetch_error,omitempty"`
 	CreatedAt     time.Time  `json:"created_at"`
@@ -34,4 +35,5 @@ type FeedRepository interface {
 	Delete(id uuid.UUID) error
 	GetFeedsToFetch(limit int) ([]*Feed, error)
 	UpdateFetchStatus(id uuid.UUID, fetchedAt time.Time, fetchError string) error
+	AddLanguages(id uuid.UUID, languages []string) error
 }
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Highlight is a user's annotation on a text range of an article. The range
+// is anchored by the quoted text and its surrounding context (prefix and
+// suffix), so it can be re-located when the rendered HTML changes;
+// StartOffset is an optional position hint.
+type Highlight struct {
+	ID          uuid.UUID `json:"id"`
+	UserID      uuid.UUID `json:"user_id"`
+	ArticleID   uuid.UUID `json:"article_id"`
+	Quote       string    `json:"quote"`
+	Prefix      string    `json:"prefix,omitempty"`
+	Suffix      string    `json:"suffix,omitempty"`
+	StartOffset *int      `json:"start_offset,omitempty"`
+	Color       string    `json:"color"`
+	Note        string    `json:"note,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Virtual fields (from joins)
+	ArticleTitle string `json:"article_title,omitempty"`
+	ArticleURL   string `json:"article_url,omitempty"`
+	FeedTitle    string `json:"feed_title,omitempty"`
+}
+
+// HighlightRepository defines the interface for highlight data access.
+type HighlightRepository interface {
+	Create(h *Highlight) error
+	GetByID(id uuid.UUID) (*Highlight, error)
+	GetByArticle(userID, articleID uuid.UUID) ([]*Highlight, error)
+	GetByArticles(userID uuid.UUID, articleIDs []uuid.UUID) (map[uuid.UUID][]*Highlight, error)
+	GetByUserID(userID uuid.UUID, limit, offset int) ([]*Highlight, error)
+	Update(h *Highlight) error
+	Delete(id uuid.UUID) error
+}
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FeedIcon is the favicon resolved for a feed's website.
+type FeedIcon struct {
+	FeedID    uuid.UUID `json:"feed_id"`
+	Data      []byte    `json:"-"`
+	MimeType  string    `json:"mime_type,omitempty"`
+	SourceURL string    `json:"source_url,omitempty"`
+	Hash      string    `json:"hash,omitempty"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// FeedIconRepository defines the interface for feed icon data access.
+type FeedIconRepository interface {
+	Get(feedID uuid.UUID) (*FeedIcon, error)
+	Upsert(icon *FeedIcon) error
+}
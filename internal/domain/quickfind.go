@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuickFindFeed is a feed matched by the quick switcher.
+type QuickFindFeed struct {
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	URL         string    `json:"url"`
+	SiteURL     string    `json:"site_url,omitempty"`
+	Category    string    `json:"category,omitempty"`
+	UnreadCount int       `json:"unread_count"`
+	Score       float32   `json:"score"`
+}
+
+// QuickFindCategory is a feed category matched by the quick switcher.
+type QuickFindCategory struct {
+	Name        string  `json:"name"`
+	FeedCount   int     `json:"feed_count"`
+	UnreadCount int     `json:"unread_count"`
+	Score       float32 `json:"score"`
+}
+
+// QuickFindArticle is an article matched by title in the quick switcher.
+type QuickFindArticle struct {
+	ID          uuid.UUID  `json:"id"`
+	FeedID      uuid.UUID  `json:"feed_id"`
+	FeedTitle   string     `json:"feed_title"`
+	Title       string     `json:"title"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	IsRead      bool       `json:"is_read"`
+	Score       float32    `json:"score"`
+}
+
+// QuickFindResults groups the quick switcher matches by kind, best first.
+type QuickFindResults struct {
+	Feeds      []*QuickFindFeed     `json:"feeds"`
+	Categories []*QuickFindCategory `json:"categories"`
+	Articles   []*QuickFindArticle  `json:"articles"`
+}
+
+// QuickFindRepository defines the interface for the quick switcher lookups.
+// Matching is fuzzy: substrings, prefixes and titles with typos all match.
+type QuickFindRepository interface {
+	FindFeeds(userID uuid.UUID, query string, limit int) ([]*QuickFindFeed, error)
+	FindCategories(userID uuid.UUID, query string, limit int) ([]*QuickFindCategory, error)
+	FindArticles(userID uuid.UUID, query string, limit int) ([]*QuickFindArticle, error)
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReadingEventType is the kind of a reading history entry.
+type ReadingEventType string
+
+// Reading event types. A read event is recorded when an article goes from
+// unread to read; an open event when the client reports the article was
+// opened, with the time spent on it when known.
+const (
+	ReadingEventRead ReadingEventType = "read"
+	ReadingEventOpen ReadingEventType = "open"
+)
+
+// ReadingEvent is an entry of a user's reading history. ArticleID and FeedID
+// are cleared when the article or feed is deleted; the event is kept for the
+// statistics.
+type ReadingEvent struct {
+	ID           uuid.UUID        `json:"id"`
+	UserID       uuid.UUID        `json:"-"`
+	ArticleID    *uuid.UUID       `json:"article_id,omitempty"`
+	FeedID       *uuid.UUID       `json:"feed_id,omitempty"`
+	Type         ReadingEventType `json:"type"`
+	DwellSeconds *int             `json:"dwell_seconds,omitempty"`
+	CreatedAt    time.Time        `json:"created_at"`
+}
+
+// DailyReadCount is the number of articles read on a calendar day.
+type DailyReadCount struct {
+	Date  string `json:"date"`
+	Count int    `json:"count"`
+}
+
+// FeedReadCount is the number of articles read from a feed.
+type FeedReadCount struct {
+	FeedID    uuid.UUID `json:"feed_id"`
+	FeedTitle string    `json:"feed_title"`
+	Count     int       `json:"count"`
+}
+
+// CategoryReadCount is the number of articles read in a feed category. An
+// empty category groups the uncategorized feeds.
+type CategoryReadCount struct {
+	Category string `json:"category"`
+	Count    int    `json:"count"`
+}
+
+// ReadingStats summarizes a user's reading over a period.
+type ReadingStats struct {
+	From                  time.Time           `json:"from"`
+	To                    time.Time           `json:"to"`
+	Timezone              string              `json:"timezone"`
+	TotalRead             int                 `json:"total_read"`
+	PerDay                []DailyReadCount    `json:"per_day"`
+	PerFeed               []FeedReadCount     `json:"per_feed"`
+	PerCategory           []CategoryReadCount `json:"per_category"`
+	TopFeeds              []FeedReadCount     `json:"top_feeds"`
+	AverageReadingSeconds float64             `json:"average_reading_seconds"`
+	TimedOpens            int                 `json:"timed_opens"`
+	CurrentStreak         int                 `json:"current_streak"`
+	LongestStreak         int                 `json:"longest_streak"`
+}
+
+// ReadingRepository defines the interface for reading history data access.
+// Days are calendar days in the given IANA time zone.
+type ReadingRepository interface {
+	Record(event *ReadingEvent) error
+	ReadsPerDay(userID uuid.UUID, since time.Time, tz string) ([]DailyReadCount, error)
+	ReadsPerFeed(userID uuid.UUID, since time.Time) ([]FeedReadCount, error)
+	ReadsPerCategory(userID uuid.UUID, since time.Time) ([]CategoryReadCount, error)
+	AverageDwell(userID uuid.UUID, since time.Time) (float64, int, error)
+	ReadDays(userID uuid.UUID, tz string) ([]string, error)
+}
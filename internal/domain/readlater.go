@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReadLaterItem is an article in a user's read-later queue. A snoozed item
+// is hidden (the article is marked read) until SnoozedUntil, then comes back
+// as unread.
+type ReadLaterItem struct {
+	ArticleID    uuid.UUID  `json:"article_id"`
+	UserID       uuid.UUID  `json:"-"`
+	Position     float64    `json:"position"`
+	SnoozedUntil *time.Time `json:"snoozed_until,omitempty"`
+	AddedAt      time.Time  `json:"added_at"`
+
+	Article *Article `json:"article,omitempty"`
+}
+
+// ReadLaterRepository defines the interface for read-later queue access.
+type ReadLaterRepository interface {
+	Add(userID, articleID uuid.UUID, top bool, snoozeUntil *time.Time) (*ReadLaterItem, error)
+	Remove(userID, articleID uuid.UUID) (bool, error)
+	List(userID uuid.UUID, includeSnoozed bool, limit, offset int) ([]*ReadLaterItem, error)
+	Reorder(userID uuid.UUID, articleIDs []uuid.UUID) error
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RetentionScope is the level a retention policy applies to.
+type RetentionScope string
+
+// Retention policy scopes, from the most general to the most specific.
+const (
+	RetentionScopeInstance RetentionScope = "instance"
+	RetentionScopeUser     RetentionScope = "user"
+	RetentionScopeFeed     RetentionScope = "feed"
+)
+
+// RetentionPolicy decides when the cleaner deletes articles. The articles of
+// a feed follow its feed policy, else its owner's user policy, else the
+// instance policy.
+//
+// An article is deleted when it is older than MaxAgeDays (by ingest time) or
+// not among the MaxArticles latest of its feed. Without either limit the
+// policy never deletes anything. KeepUnread keeps unread articles forever.
+// Favorite, tagged, highlighted and archived articles and articles in the
+// read-later queue are always kept.
+type RetentionPolicy struct {
+	ID          uuid.UUID      `json:"id"`
+	Scope       RetentionScope `json:"scope"`
+	UserID      *uuid.UUID     `json:"user_id,omitempty"`
+	FeedID      *uuid.UUID     `json:"feed_id,omitempty"`
+	MaxAgeDays  *int           `json:"max_age_days"`
+	MaxArticles *int           `json:"max_articles"`
+	KeepUnread  bool           `json:"keep_unread"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// NeverDeletes reports whether the policy keeps every article.
+func (p *RetentionPolicy) NeverDeletes() bool {
+	return p.MaxAgeDays == nil && p.MaxArticles == nil
+}
+
+// RetentionPurgeCount is the number of articles a policy would delete, out
+// of the feeds it currently governs.
+type RetentionPurgeCount struct {
+	Policy   *RetentionPolicy `json:"policy"`
+	Feeds    int              `json:"feeds"`
+	Articles int64            `json:"articles"`
+}
+
+// RetentionRepository defines the interface for retention policy access and
+// enforcement.
+type RetentionRepository interface {
+	Create(policy *RetentionPolicy) error
+	Update(policy *RetentionPolicy) error
+	Delete(id uuid.UUID) error
+	GetInstance() (*RetentionPolicy, error)
+	GetForUser(userID uuid.UUID) (*RetentionPolicy, error)
+	GetForFeed(feedID uuid.UUID) (*RetentionPolicy, error)
+	ListFeedPolicies(userID uuid.UUID) ([]*RetentionPolicy, error)
+
+	// PurgeCounts reports what Purge would delete, per effective policy. With
+	// a user ID only that user's feeds are considered.
+	PurgeCounts(ctx context.Context, userID uuid.UUID) ([]*RetentionPurgeCount, error)
+	Purge(ctx context.Context) (int64, error)
+}
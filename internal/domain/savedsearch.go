@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SavedSearchFilters are the article filters stored with a saved search.
+// MaxAgeDays is relative to the time the search runs, so that a smart folder
+// like "last week" keeps moving.
+type SavedSearchFilters struct {
+	FeedIDs    []uuid.UUID `json:"feed_ids,omitempty"`
+	Category   string      `json:"category,omitempty"`
+	Tags       []string    `json:"tags,omitempty"`
+	Author     string      `json:"author,omitempty"`
+	IsFavorite *bool       `json:"favorite,omitempty"`
+	HasImage   *bool       `json:"has_image,omitempty"`
+	MaxAgeDays int         `json:"max_age_days,omitempty"`
+}
+
+// SavedSearch is a named query (full-text terms plus filters) shown as a
+// smart folder next to the feeds.
+type SavedSearch struct {
+	ID        uuid.UUID          `json:"id"`
+	UserID    uuid.UUID          `json:"user_id"`
+	Name      string             `json:"name"`
+	Query     string             `json:"query"`
+	Filters   SavedSearchFilters `json:"filters"`
+	Position  int                `json:"position"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+
+	// Virtual fields (aggregated)
+	UnreadCount int `json:"unread_count"`
+}
+
+// SavedSearchRepository defines the interface for saved search data access.
+type SavedSearchRepository interface {
+	Create(search *SavedSearch) error
+	GetByID(id uuid.UUID) (*SavedSearch, error)
+	GetByUserID(userID uuid.UUID) ([]*SavedSearch, error)
+	GetByName(userID uuid.UUID, name string) (*SavedSearch, error)
+	Update(search *SavedSearch) error
+	Delete(id uuid.UUID) error
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tag is a user-defined label that can be applied to articles.
+type Tag struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Virtual fields (aggregated)
+	ArticleCount int `json:"article_count"`
+	UnreadCount  int `json:"unread_count"`
+}
+
+// ErrTagExists is returned when a tag would take a name the user already
+// uses, ignoring case.
+var ErrTagExists = errors.New("tag already exists")
+
+// TagRepository defines the interface for tag data access.
+type TagRepository interface {
+	Create(tag *Tag) (bool, error)
+	GetByID(id uuid.UUID) (*Tag, error)
+	GetByUserID(userID uuid.UUID) ([]*Tag, error)
+	GetByNames(userID uuid.UUID, names []string) ([]*Tag, error)
+	Update(tag *Tag) error
+	Delete(id uuid.UUID) error
+	AddToArticles(userID uuid.UUID, tagIDs, articleIDs []uuid.UUID) ([]uuid.UUID, error)
+	RemoveFromArticles(userID uuid.UUID, tagIDs, articleIDs []uuid.UUID) ([]uuid.UUID, error)
+	SetForArticle(articleID uuid.UUID, tagIDs []uuid.UUID) error
+}
@@ -16,6 +16,7 @@ type User struct {
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 	Role         string    `json:"role"`
+	ProxyImages  bool      `json:"proxy_images"`
 }
 
 const (
@@ -31,4 +32,5 @@ type UserRepository interface {
 	Exists(email string) (bool, error)
 	List() ([]*User, error)
 	Delete(id uuid.UUID) error
+	UpdatePreferences(id uuid.UUID, proxyImages bool) error
 }
@@ -0,0 +1,318 @@
+// Package epub writes EPUB 3 books in pure Go. Books are streamed: chapters
+// and images go straight into the zip container as they are added, and the
+// package document and navigation are written on Close.
+//
+// The layout is the conventional one:
+//
+//	mimetype
+//	META-INF/container.xml
+//	OEBPS/content.opf, nav.xhtml, toc.ncx, style.css
+//	OEBPS/text/chapter-NNNN.xhtml
+//	OEBPS/images/image-NNNN.ext
+package epub
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrUnsupportedImage is returned for image types outside the EPUB core
+// media types.
+var ErrUnsupportedImage = errors.New("unsupported image type")
+
+// imageExtensions maps the EPUB core image media types to file extensions.
+var imageExtensions = map[string]string{
+	"image/jpeg":    "jpg",
+	"image/png":     "png",
+	"image/gif":     "gif",
+	"image/webp":    "webp",
+	"image/svg+xml": "svg",
+}
+
+// Metadata describes a book.
+type Metadata struct {
+	Identifier  uuid.UUID
+	Title       string
+	Language    string
+	Creator     string
+	Publisher   string
+	Description string
+	Modified    time.Time
+}
+
+// Chapter is a section of the book with its own entry in the table of
+// contents. Body is an HTML fragment: it is converted to XHTML, keeping only
+// the elements e-readers render and the images added to the book.
+type Chapter struct {
+	Title    string
+	Language string
+	Body     string
+}
+
+type manifestItem struct {
+	ID        string
+	Href      string
+	MediaType string
+	Title     string
+}
+
+// Writer writes an EPUB file.
+type Writer struct {
+	zw       *zip.Writer
+	meta     Metadata
+	chapters []manifestItem
+	images   []manifestItem
+}
+
+// NewWriter starts a book on w.
+func NewWriter(w io.Writer, meta Metadata) (*Writer, error) {
+	if meta.Identifier == uuid.Nil {
+		meta.Identifier = uuid.New()
+	}
+	if meta.Language == "" {
+		meta.Language = "en"
+	}
+	if meta.Modified.IsZero() {
+		meta.Modified = time.Now()
+	}
+
+	ew := &Writer{zw: zip.NewWriter(w), meta: meta}
+
+	// The mimetype must come first, uncompressed, without extra fields (so
+	// without a modification time) and without a trailing data descriptor
+	mimetype := []byte("application/epub+zip")
+	mw, err := ew.zw.CreateRaw(&zip.FileHeader{
+		Name:               "mimetype",
+		Method:             zip.Store,
+		CRC32:              crc32.ChecksumIEEE(mimetype),
+		CompressedSize64:   uint64(len(mimetype)),
+		UncompressedSize64: uint64(len(mimetype)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("writing mimetype: %w", err)
+	}
+	if _, err := mw.Write(mimetype); err != nil {
+		return nil, fmt.Errorf("writing mimetype: %w", err)
+	}
+
+	if err := ew.writeFile("META-INF/container.xml", containerXML); err != nil {
+		return nil, err
+	}
+	if err := ew.writeFile("OEBPS/style.css", styleCSS); err != nil {
+		return nil, err
+	}
+
+	return ew, nil
+}
+
+func (w *Writer) writeFile(name, content string) error {
+	f, err := w.zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: w.meta.Modified})
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", name, err)
+	}
+	if _, err := io.WriteString(f, content); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	return nil
+}
+
+// AddImage stores an image and returns the path chapters refer to it by.
+func (w *Writer) AddImage(data []byte, mediaType string) (string, error) {
+	ext, ok := imageExtensions[mediaType]
+	if !ok {
+		return "", ErrUnsupportedImage
+	}
+
+	n := len(w.images) + 1
+	item := manifestItem{
+		ID:        fmt.Sprintf("image-%04d", n),
+		Href:      fmt.Sprintf("images/image-%04d.%s", n, ext),
+		MediaType: mediaType,
+	}
+
+	// Images are already compressed
+	f, err := w.zw.CreateHeader(&zip.FileHeader{Name: "OEBPS/" + item.Href, Method: zip.Store, Modified: w.meta.Modified})
+	if err != nil {
+		return "", fmt.Errorf("creating image: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		return "", fmt.Errorf("writing image: %w", err)
+	}
+
+	w.images = append(w.images, item)
+	return "../" + item.Href, nil
+}
+
+// AddChapter appends a chapter to the book.
+func (w *Writer) AddChapter(ch Chapter) error {
+	n := len(w.chapters) + 1
+	item := manifestItem{
+		ID:        fmt.Sprintf("chapter-%04d", n),
+		Href:      fmt.Sprintf("text/chapter-%04d.xhtml", n),
+		MediaType: "application/xhtml+xml",
+		Title:     strings.TrimSpace(ch.Title),
+	}
+	if item.Title == "" {
+		item.Title = fmt.Sprintf("Chapter %d", n)
+	}
+
+	lang := ch.Language
+	if lang == "" {
+		lang = w.meta.Language
+	}
+
+	var b strings.Builder
+	b.WriteString(xmlHeader)
+	fmt.Fprintf(&b, `<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" xml:lang="%[1]s" lang="%[1]s">
+<head>
+<meta charset="utf-8"/>
+<title>%[2]s</title>
+<link rel="stylesheet" type="text/css" href="../style.css"/>
+</head>
+<body>
+`, escape(lang), escape(item.Title))
+	b.WriteString(XHTML(ch.Body))
+	b.WriteString("\n</body>\n</html>\n")
+
+	if err := w.writeFile("OEBPS/"+item.Href, b.String()); err != nil {
+		return err
+	}
+
+	w.chapters = append(w.chapters, item)
+	return nil
+}
+
+// Close writes the navigation and package documents and finishes the zip
+// container. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if err := w.writeFile("OEBPS/nav.xhtml", w.navXHTML()); err != nil {
+		return err
+	}
+	if err := w.writeFile("OEBPS/toc.ncx", w.tocNCX()); err != nil {
+		return err
+	}
+	if err := w.writeFile("OEBPS/content.opf", w.contentOPF()); err != nil {
+		return err
+	}
+	return w.zw.Close()
+}
+
+// navXHTML is the EPUB 3 navigation document, also the first page.
+func (w *Writer) navXHTML() string {
+	var b strings.Builder
+	b.WriteString(xmlHeader)
+	fmt.Fprintf(&b, `<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" xml:lang="%[1]s" lang="%[1]s">
+<head>
+<meta charset="utf-8"/>
+<title>%[2]s</title>
+<link rel="stylesheet" type="text/css" href="style.css"/>
+</head>
+<body>
+<h1>%[2]s</h1>
+`, escape(w.meta.Language), escape(w.meta.Title))
+	if w.meta.Description != "" {
+		fmt.Fprintf(&b, "<p class=\"description\">%s</p>\n", escape(w.meta.Description))
+	}
+	b.WriteString("<nav epub:type=\"toc\" id=\"toc\">\n<h2>Contents</h2>\n<ol>\n")
+	for _, ch := range w.chapters {
+		fmt.Fprintf(&b, "<li><a href=\"%s\">%s</a></li>\n", ch.Href, escape(ch.Title))
+	}
+	b.WriteString("</ol>\n</nav>\n</body>\n</html>\n")
+	return b.String()
+}
+
+// tocNCX is the EPUB 2 table of contents, for older readers.
+func (w *Writer) tocNCX() string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="utf-8"?>` + "\n")
+	fmt.Fprintf(&b, `<ncx xmlns="http://www.daisy.org/z3986/2005/ncx/" version="2005-1">
+<head>
+<meta name="dtb:uid" content="urn:uuid:%s"/>
+<meta name="dtb:depth" content="1"/>
+<meta name="dtb:totalPageCount" content="0"/>
+<meta name="dtb:maxPageNumber" content="0"/>
+</head>
+<docTitle><text>%s</text></docTitle>
+<navMap>
+`, w.meta.Identifier, escape(w.meta.Title))
+	for i, ch := range w.chapters {
+		fmt.Fprintf(&b, "<navPoint id=\"nav-%[1]d\" playOrder=\"%[1]d\"><navLabel><text>%[2]s</text></navLabel><content src=\"%[3]s\"/></navPoint>\n",
+			i+1, escape(ch.Title), ch.Href)
+	}
+	b.WriteString("</navMap>\n</ncx>\n")
+	return b.String()
+}
+
+// contentOPF is the package document: metadata, manifest and reading order.
+func (w *Writer) contentOPF() string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="utf-8"?>` + "\n")
+	b.WriteString(`<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="book-id">` + "\n")
+	b.WriteString(`<metadata xmlns:dc="http://purl.org/dc/elements/1.1/">` + "\n")
+	fmt.Fprintf(&b, "<dc:identifier id=\"book-id\">urn:uuid:%s</dc:identifier>\n", w.meta.Identifier)
+	fmt.Fprintf(&b, "<dc:title>%s</dc:title>\n", escape(w.meta.Title))
+	fmt.Fprintf(&b, "<dc:language>%s</dc:language>\n", escape(w.meta.Language))
+	if w.meta.Creator != "" {
+		fmt.Fprintf(&b, "<dc:creator>%s</dc:creator>\n", escape(w.meta.Creator))
+	}
+	if w.meta.Publisher != "" {
+		fmt.Fprintf(&b, "<dc:publisher>%s</dc:publisher>\n", escape(w.meta.Publisher))
+	}
+	if w.meta.Description != "" {
+		fmt.Fprintf(&b, "<dc:description>%s</dc:description>\n", escape(w.meta.Description))
+	}
+	fmt.Fprintf(&b, "<dc:date>%s</dc:date>\n", w.meta.Modified.UTC().Format("2006-01-02"))
+	fmt.Fprintf(&b, "<meta property=\"dcterms:modified\">%s</meta>\n", w.meta.Modified.UTC().Format("2006-01-02T15:04:05Z"))
+	b.WriteString("</metadata>\n<manifest>\n")
+	b.WriteString(`<item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>` + "\n")
+	b.WriteString(`<item id="ncx" href="toc.ncx" media-type="application/x-dtbncx+xml"/>` + "\n")
+	b.WriteString(`<item id="style" href="style.css" media-type="text/css"/>` + "\n")
+	for _, ch := range w.chapters {
+		fmt.Fprintf(&b, "<item id=\"%s\" href=\"%s\" media-type=\"%s\"/>\n", ch.ID, ch.Href, ch.MediaType)
+	}
+	for _, img := range w.images {
+		fmt.Fprintf(&b, "<item id=\"%s\" href=\"%s\" media-type=\"%s\"/>\n", img.ID, img.Href, img.MediaType)
+	}
+	b.WriteString("</manifest>\n<spine toc=\"ncx\">\n<itemref idref=\"nav\"/>\n")
+	for _, ch := range w.chapters {
+		fmt.Fprintf(&b, "<itemref idref=\"%s\"/>\n", ch.ID)
+	}
+	b.WriteString("</spine>\n</package>\n")
+	return b.String()
+}
+
+// escape escapes text for XML content and attribute values.
+func escape(s string) string {
+	return html.EscapeString(xmlChars(s))
+}
+
+const xmlHeader = `<?xml version="1.0" encoding="utf-8"?>
+<!DOCTYPE html>
+`
+
+const containerXML = `<?xml version="1.0" encoding="utf-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+<rootfiles>
+<rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+</rootfiles>
+</container>
+`
+
+const styleCSS = `body { font-family: serif; line-height: 1.5; margin: 0 0.5em; }
+h1 { font-size: 1.5em; line-height: 1.2; margin: 0.5em 0; }
+h2 { font-size: 1.3em; } h3 { font-size: 1.15em; }
+p.meta, p.description { color: #555; font-size: 0.85em; }
+blockquote { margin: 1em 1.5em; font-style: italic; }
+img { max-width: 100%; height: auto; }
+figure { margin: 1em 0; } figcaption { font-size: 0.85em; color: #555; }
+pre { white-space: pre-wrap; font-size: 0.85em; }
+table { border-collapse: collapse; } td, th { border: 1px solid #999; padding: 0.2em 0.4em; }
+`
@@ -0,0 +1,220 @@
+package epub
+
+import (
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	nethtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// keptElements are rendered as-is. Elements not listed here are unwrapped,
+// keeping their content, unless they are in droppedElements.
+var keptElements = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Span: true, atom.Br: true, atom.Hr: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.A: true, atom.Em: true, atom.Strong: true, atom.B: true, atom.I: true, atom.U: true,
+	atom.S: true, atom.Del: true, atom.Ins: true, atom.Sub: true, atom.Sup: true,
+	atom.Small: true, atom.Mark: true, atom.Abbr: true, atom.Cite: true, atom.Q: true, atom.Time: true,
+	atom.Code: true, atom.Pre: true, atom.Kbd: true, atom.Samp: true, atom.Blockquote: true,
+	atom.Ul: true, atom.Ol: true, atom.Li: true, atom.Dl: true, atom.Dt: true, atom.Dd: true,
+	atom.Table: true, atom.Caption: true, atom.Thead: true, atom.Tbody: true, atom.Tfoot: true,
+	atom.Tr: true, atom.Th: true, atom.Td: true, atom.Colgroup: true, atom.Col: true,
+	atom.Figure: true, atom.Figcaption: true, atom.Img: true,
+	atom.Article: true, atom.Section: true, atom.Header: true, atom.Footer: true, atom.Aside: true,
+}
+
+// droppedElements are removed with their content: scripts, embedded media
+// and forms, which e-readers don't render.
+var droppedElements = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Template: true,
+	atom.Iframe: true, atom.Object: true, atom.Embed: true, atom.Video: true, atom.Audio: true,
+	atom.Source: true, atom.Track: true, atom.Canvas: true, atom.Svg: true, atom.Math: true,
+	atom.Form: true, atom.Input: true, atom.Button: true, atom.Select: true, atom.Textarea: true,
+	atom.Head: true, atom.Title: true, atom.Meta: true, atom.Link: true,
+}
+
+// voidElements are written as self-closing tags.
+var voidElements = map[atom.Atom]bool{
+	atom.Br: true, atom.Hr: true, atom.Img: true, atom.Col: true,
+}
+
+// keptAttributes lists the attributes kept per element; title is kept on all.
+var keptAttributes = map[atom.Atom][]string{
+	atom.A:        {"href"},
+	atom.Img:      {"src", "alt", "width", "height"},
+	atom.Td:       {"colspan", "rowspan"},
+	atom.Th:       {"colspan", "rowspan", "scope"},
+	atom.Col:      {"span"},
+	atom.Colgroup: {"span"},
+	atom.Ol:       {"start", "reversed"},
+	atom.Time:     {"datetime"},
+	atom.P:        {"class"},
+}
+
+// XHTML converts an HTML fragment to well-formed XHTML for a chapter. Only
+// the elements and attributes e-readers render are kept, links must be
+// absolute http(s) or mailto URLs, and images must point inside the book
+// (paths returned by AddImage): other images are replaced by their alt text.
+func XHTML(fragment string) string {
+	nodes, err := nethtml.ParseFragment(strings.NewReader(fragment), &nethtml.Node{
+		Type:     nethtml.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return "<p>" + escape(fragment) + "</p>"
+	}
+
+	var b strings.Builder
+	for _, n := range nodes {
+		writeXHTML(&b, n)
+	}
+	return b.String()
+}
+
+func writeXHTML(b *strings.Builder, n *nethtml.Node) {
+	switch n.Type {
+	case nethtml.TextNode:
+		b.WriteString(escape(n.Data))
+		return
+	case nethtml.ElementNode:
+	default:
+		// Comments and doctypes are dropped
+		return
+	}
+
+	if droppedElements[n.DataAtom] || n.DataAtom == 0 && n.Namespace != "" {
+		return
+	}
+	if !keptElements[n.DataAtom] {
+		writeChildren(b, n)
+		return
+	}
+
+	attrs := xhtmlAttributes(n)
+	if n.DataAtom == atom.Img && attrs == nil {
+		// Remote image: keep its description
+		for _, a := range n.Attr {
+			if a.Key == "alt" && strings.TrimSpace(a.Val) != "" {
+				b.WriteString(escape(a.Val))
+			}
+		}
+		return
+	}
+
+	b.WriteString("<" + n.Data)
+	for _, a := range attrs {
+		b.WriteString(" " + a.Key + `="` + escape(a.Val) + `"`)
+	}
+	if voidElements[n.DataAtom] {
+		b.WriteString("/>")
+		return
+	}
+	b.WriteString(">")
+	writeChildren(b, n)
+	b.WriteString("</" + n.Data + ">")
+}
+
+func writeChildren(b *strings.Builder, n *nethtml.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		writeXHTML(b, c)
+	}
+}
+
+// xhtmlAttributes returns the attributes kept on an element. For images it
+// returns nil unless the source is inside the book.
+func xhtmlAttributes(n *nethtml.Node) []nethtml.Attribute {
+	allowed := keptAttributes[n.DataAtom]
+	attrs := []nethtml.Attribute{}
+	hasSrc := false
+	for _, a := range n.Attr {
+		if a.Namespace != "" {
+			continue
+		}
+		key := strings.ToLower(a.Key)
+		if key != "title" && !contains(allowed, key) {
+			continue
+		}
+		switch key {
+		case "href":
+			if !linkAllowed(a.Val) {
+				continue
+			}
+		case "src":
+			if !strings.HasPrefix(a.Val, "../images/") {
+				continue
+			}
+			hasSrc = true
+		}
+		attrs = append(attrs, nethtml.Attribute{Key: key, Val: a.Val})
+	}
+
+	if n.DataAtom == atom.Img {
+		if !hasSrc {
+			return nil
+		}
+		// alt is required on images
+		hasAlt := false
+		for _, a := range attrs {
+			hasAlt = hasAlt || a.Key == "alt"
+		}
+		if !hasAlt {
+			attrs = append(attrs, nethtml.Attribute{Key: "alt", Val: ""})
+		}
+	}
+	return attrs
+}
+
+// linkAllowed reports whether a link target can be kept: chapters are
+// separate documents, so relative links would point nowhere.
+func linkAllowed(href string) bool {
+	u, err := url.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+		return u.Host != ""
+	case "mailto":
+		return true
+	}
+	return false
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// xmlChars removes the characters XML 1.0 does not allow, such as most
+// control characters, and invalid UTF-8.
+func xmlChars(s string) string {
+	valid := func(r rune) bool {
+		return r == '\t' || r == '\n' || r == '\r' ||
+			r >= 0x20 && r <= 0xD7FF || r >= 0xE000 && r <= 0xFFFD || r >= 0x10000 && r <= 0x10FFFF
+	}
+
+	clean := true
+	for _, r := range s {
+		if r == utf8.RuneError || !valid(r) {
+			clean = false
+			break
+		}
+	}
+	if clean {
+		return s
+	}
+
+	return strings.Map(func(r rune) rune {
+		if r == utf8.RuneError || !valid(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
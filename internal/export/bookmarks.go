@@ -0,0 +1,74 @@
+package export
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/michael/flowreader/internal/utils"
+)
+
+// bookmarksWriter writes the Netscape bookmark file format understood by
+// browsers and bookmarking services. Tags go in the TAGS attribute and the
+// AI summary (else the feed summary) in the description.
+type bookmarksWriter struct {
+	w     io.Writer
+	title string
+}
+
+// maxBookmarkDescription caps bookmark descriptions, in bytes.
+const maxBookmarkDescription = 1000
+
+func (b *bookmarksWriter) Begin() error {
+	title := html.EscapeString(b.title)
+	_, err := fmt.Fprintf(b.w, `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<!-- This is an automatically generated file. -->
+<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
+<TITLE>%s</TITLE>
+<H1>%s</H1>
+<DL><p>
+`, title, title)
+	return err
+}
+
+func (b *bookmarksWriter) Write(item *Item) error {
+	a := item.Article
+	if a.URL == "" {
+		return nil
+	}
+
+	added := a.CreatedAt
+	if a.FavoritedAt != nil {
+		added = *a.FavoritedAt
+	}
+
+	var entry strings.Builder
+	fmt.Fprintf(&entry, `    <DT><A HREF="%s" ADD_DATE="%d"`, html.EscapeString(a.URL), added.Unix())
+	if len(a.Tags) > 0 {
+		tags := make([]string, len(a.Tags))
+		for i, tag := range a.Tags {
+			tags[i] = strings.ReplaceAll(tag, ",", " ")
+		}
+		fmt.Fprintf(&entry, ` TAGS="%s"`, html.EscapeString(strings.Join(tags, ",")))
+	}
+	fmt.Fprintf(&entry, ">%s</A>\n", html.EscapeString(a.Title))
+
+	description := a.AISummary
+	if description == "" {
+		description = utils.HTMLToText(a.Summary)
+	}
+	description = strings.Join(strings.Fields(description), " ")
+	if description != "" {
+		description = utils.TruncateUTF8(description, maxBookmarkDescription)
+		fmt.Fprintf(&entry, "    <DD>%s\n", html.EscapeString(description))
+	}
+
+	_, err := io.WriteString(b.w, entry.String())
+	return err
+}
+
+func (b *bookmarksWriter) End() error {
+	_, err := io.WriteString(b.w, "</DL><p>\n")
+	return err
+}
@@ -0,0 +1,104 @@
+// Package export writes articles, with their tags, AI summaries and
+// highlights, in portable formats: JSON, Markdown, a standalone HTML page and
+// Netscape bookmarks. Writers stream: items are written as they come, so
+// exports of any size use constant memory.
+package export
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/michael/flowreader/internal/domain"
+)
+
+// Format is an export file format.
+type Format string
+
+// Export formats
+const (
+	FormatJSON      Format = "json"
+	FormatMarkdown  Format = "markdown"
+	FormatHTML      Format = "html"
+	FormatBookmarks Format = "bookmarks"
+)
+
+// ErrUnknownFormat is returned for unsupported export formats.
+var ErrUnknownFormat = errors.New("unknown export format")
+
+// ParseFormat parses an export format name. "md" is accepted for Markdown.
+func ParseFormat(name string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(name))); f {
+	case FormatJSON, FormatMarkdown, FormatHTML, FormatBookmarks:
+		return f, nil
+	case "md":
+		return FormatMarkdown, nil
+	default:
+		return "", ErrUnknownFormat
+	}
+}
+
+// ContentType returns the MIME type of the format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatJSON:
+		return "application/json; charset=utf-8"
+	case FormatMarkdown:
+		return "text/markdown; charset=utf-8"
+	default:
+		return "text/html; charset=utf-8"
+	}
+}
+
+// Extension returns the file name extension of the format.
+func (f Format) Extension() string {
+	switch f {
+	case FormatJSON:
+		return "json"
+	case FormatMarkdown:
+		return "md"
+	default:
+		return "html"
+	}
+}
+
+// Item is an exported article with the user's highlights on it.
+type Item struct {
+	Article    *domain.Article
+	Highlights []*domain.Highlight
+}
+
+// Writer streams items in an export format. Begin is called once before the
+// first item and End once after the last one.
+type Writer interface {
+	Begin() error
+	Write(item *Item) error
+	End() error
+}
+
+// NewWriter returns a writer of the given format. The title names the export
+// in formats that have a document title.
+func NewWriter(format Format, w io.Writer, title string, exportedAt time.Time) (Writer, error) {
+	switch format {
+	case FormatJSON:
+		return &jsonWriter{w: w, title: title, exportedAt: exportedAt}, nil
+	case FormatMarkdown:
+		return &markdownWriter{w: w, title: title, exportedAt: exportedAt}, nil
+	case FormatHTML:
+		return &htmlWriter{w: w, title: title, exportedAt: exportedAt}, nil
+	case FormatBookmarks:
+		return &bookmarksWriter{w: w, title: title}, nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// itemDate returns the date shown for an article: its publication date,
+// else the time it was fetched.
+func itemDate(a *domain.Article) time.Time {
+	if a.PublishedAt != nil {
+		return *a.PublishedAt
+	}
+	return a.CreatedAt
+}
@@ -0,0 +1,76 @@
+package export
+
+import (
+	"html/template"
+	"io"
+	"time"
+)
+
+// htmlWriter writes a standalone, self-styled HTML page with one section per
+// article. Article content is the sanitized HTML stored at ingest.
+type htmlWriter struct {
+	w          io.Writer
+	title      string
+	exportedAt time.Time
+}
+
+var htmlTemplates = template.Must(template.New("begin").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { max-width: 46rem; margin: 2rem auto; padding: 0 1rem; font: 1rem/1.6 Georgia, serif; color: #222; }
+article { border-bottom: 1px solid #ddd; padding-bottom: 2rem; margin-bottom: 2rem; }
+.meta { color: #666; font-size: 0.9rem; }
+.tag { display: inline-block; background: #eee; border-radius: 3px; padding: 0 0.4rem; margin-right: 0.3rem; }
+.summary { background: #f6f6f6; padding: 0.5rem 1rem; }
+blockquote.highlight { border-left: 4px solid #f5d800; margin-left: 0; padding-left: 1rem; }
+img, video { max-width: 100%; height: auto; }
+pre { overflow-x: auto; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="meta">Exported on {{.ExportedAt.Format "2 January 2006"}}</p>
+`))
+
+func init() {
+	template.Must(htmlTemplates.New("item").Parse(`<article>
+<h2>{{if .Article.URL}}<a href="{{.Article.URL}}">{{.Article.Title}}</a>{{else}}{{.Article.Title}}{{end}}</h2>
+<p class="meta">{{with .Article.FeedTitle}}{{.}} · {{end}}{{with .Article.Author}}{{.}} · {{end}}{{.Date.Format "2 January 2006"}}{{if .Article.IsFavorite}} · ★{{end}}</p>
+{{with .Article.Tags}}<p>{{range .}}<span class="tag">{{.}}</span>{{end}}</p>{{end}}
+{{with .Article.AISummary}}<div class="summary"><h3>Summary</h3><p>{{.}}</p></div>{{end}}
+{{with .Highlights}}<h3>Highlights</h3>
+{{range .}}<blockquote class="highlight"><p>{{.Quote}}</p>{{with .Note}}<footer>{{.}}</footer>{{end}}</blockquote>
+{{end}}{{end}}
+{{with .Content}}<div class="content">{{.}}</div>{{end}}
+</article>
+`))
+	template.Must(htmlTemplates.New("end").Parse("</body>\n</html>\n"))
+}
+
+func (h *htmlWriter) Begin() error {
+	return htmlTemplates.ExecuteTemplate(h.w, "begin", map[string]interface{}{
+		"Title":      h.title,
+		"ExportedAt": h.exportedAt,
+	})
+}
+
+func (h *htmlWriter) Write(item *Item) error {
+	content := item.Article.Content
+	if content == "" {
+		content = item.Article.Summary
+	}
+	return htmlTemplates.ExecuteTemplate(h.w, "item", map[string]interface{}{
+		"Article":    item.Article,
+		"Highlights": item.Highlights,
+		"Date":       itemDate(item.Article),
+		"Content":    template.HTML(content),
+	})
+}
+
+func (h *htmlWriter) End() error {
+	return htmlTemplates.ExecuteTemplate(h.w, "end", nil)
+}
@@ -0,0 +1,95 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// jsonWriter writes {"title": ..., "exported_at": ..., "articles": [...]}.
+type jsonWriter struct {
+	w          io.Writer
+	title      string
+	exportedAt time.Time
+	count      int
+}
+
+type jsonHighlight struct {
+	Quote     string    `json:"quote"`
+	Note      string    `json:"note,omitempty"`
+	Color     string    `json:"color,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type jsonArticle struct {
+	ID          uuid.UUID       `json:"id"`
+	Title       string          `json:"title"`
+	URL         string          `json:"url,omitempty"`
+	Author      string          `json:"author,omitempty"`
+	FeedTitle   string          `json:"feed_title,omitempty"`
+	PublishedAt *time.Time      `json:"published_at,omitempty"`
+	IsFavorite  bool            `json:"is_favorite"`
+	FavoritedAt *time.Time      `json:"favorited_at,omitempty"`
+	Tags        []string        `json:"tags"`
+	Summary     string          `json:"summary,omitempty"`
+	AISummary   string          `json:"ai_summary,omitempty"`
+	Content     string          `json:"content,omitempty"`
+	Highlights  []jsonHighlight `json:"highlights"`
+}
+
+func (j *jsonWriter) Begin() error {
+	title, err := json.Marshal(j.title)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(j.w, `{"title":%s,"exported_at":"%s","articles":[`, title, j.exportedAt.UTC().Format(time.RFC3339))
+	return err
+}
+
+func (j *jsonWriter) Write(item *Item) error {
+	a := item.Article
+	record := jsonArticle{
+		ID:          a.ID,
+		Title:       a.Title,
+		URL:         a.URL,
+		Author:      a.Author,
+		FeedTitle:   a.FeedTitle,
+		PublishedAt: a.PublishedAt,
+		IsFavorite:  a.IsFavorite,
+		FavoritedAt: a.FavoritedAt,
+		Tags:        a.Tags,
+		Summary:     a.Summary,
+		AISummary:   a.AISummary,
+		Content:     a.Content,
+		Highlights:  make([]jsonHighlight, len(item.Highlights)),
+	}
+	if record.Tags == nil {
+		record.Tags = []string{}
+	}
+	for i, h := range item.Highlights {
+		record.Highlights[i] = jsonHighlight{Quote: h.Quote, Note: h.Note, Color: h.Color, CreatedAt: h.CreatedAt}
+	}
+
+	// Content is HTML: keep it readable instead of escaping <, > and &
+	var buf bytes.Buffer
+	if j.count > 0 {
+		buf.WriteByte(',')
+	}
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(record); err != nil {
+		return fmt.Errorf("encoding article: %w", err)
+	}
+	j.count++
+	_, err := j.w.Write(bytes.TrimRight(buf.Bytes(), "\n"))
+	return err
+}
+
+func (j *jsonWriter) End() error {
+	_, err := io.WriteString(j.w, "]}\n")
+	return err
+}
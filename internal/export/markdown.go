@@ -0,0 +1,339 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// markdownWriter writes one section per article, with its metadata, AI
+// summary, highlights as block quotes and content converted to Markdown.
+type markdownWriter struct {
+	w          io.Writer
+	title      string
+	exportedAt time.Time
+}
+
+func (m *markdownWriter) Begin() error {
+	_, err := fmt.Fprintf(m.w, "# %s\n\nExported on %s.\n\n", m.title, m.exportedAt.UTC().Format("2006-01-02"))
+	return err
+}
+
+func (m *markdownWriter) Write(item *Item) error {
+	a := item.Article
+	var b strings.Builder
+
+	if a.URL != "" {
+		fmt.Fprintf(&b, "## [%s](%s)\n\n", escapeMarkdown(a.Title), a.URL)
+	} else {
+		fmt.Fprintf(&b, "## %s\n\n", escapeMarkdown(a.Title))
+	}
+
+	if a.FeedTitle != "" {
+		fmt.Fprintf(&b, "- Feed: %s\n", escapeMarkdown(a.FeedTitle))
+	}
+	if a.Author != "" {
+		fmt.Fprintf(&b, "- Author: %s\n", escapeMarkdown(a.Author))
+	}
+	fmt.Fprintf(&b, "- Date: %s\n", itemDate(a).Format("2006-01-02"))
+	if len(a.Tags) > 0 {
+		tags := make([]string, len(a.Tags))
+		for i, tag := range a.Tags {
+			tags[i] = "`" + strings.ReplaceAll(tag, "`", "'") + "`"
+		}
+		fmt.Fprintf(&b, "- Tags: %s\n", strings.Join(tags, ", "))
+	}
+	if a.IsFavorite {
+		b.WriteString("- Favorite\n")
+	}
+	b.WriteString("\n")
+
+	if a.AISummary != "" {
+		fmt.Fprintf(&b, "### Summary\n\n%s\n\n", strings.TrimSpace(a.AISummary))
+	}
+
+	if len(item.Highlights) > 0 {
+		b.WriteString("### Highlights\n\n")
+		for _, h := range item.Highlights {
+			b.WriteString(quoteMarkdown(escapeMarkdown(h.Quote)))
+			b.WriteString("\n\n")
+			if h.Note != "" {
+				fmt.Fprintf(&b, "%s\n\n", strings.TrimSpace(h.Note))
+			}
+		}
+	}
+
+	content := a.Content
+	if content == "" {
+		content = a.Summary
+	}
+	if body := htmlToMarkdown(content); body != "" {
+		fmt.Fprintf(&b, "### Content\n\n%s\n\n", body)
+	}
+
+	b.WriteString("---\n\n")
+	_, err := io.WriteString(m.w, b.String())
+	return err
+}
+
+func (m *markdownWriter) End() error {
+	return nil
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`,
+)
+
+// escapeMarkdown escapes the characters with an inline Markdown meaning.
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+// quoteMarkdown turns text into a block quote.
+func quoteMarkdown(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight("> "+line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+var (
+	blankLines = regexp.MustCompile(`\n{3,}`)
+	spaceRuns  = regexp.MustCompile(`[ \t\r\n\f]+`)
+)
+
+// htmlToMarkdown converts a sanitized HTML fragment to Markdown. Elements
+// without a Markdown equivalent keep their text.
+func htmlToMarkdown(fragment string) string {
+	if strings.TrimSpace(fragment) == "" {
+		return ""
+	}
+	nodes, err := html.ParseFragment(strings.NewReader(fragment), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, n := range nodes {
+		b.WriteString(markdownNode(n))
+	}
+
+	out := blankLines.ReplaceAllString(b.String(), "\n\n")
+	lines := strings.Split(out, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func markdownChildren(n *html.Node) string {
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		b.WriteString(markdownNode(c))
+	}
+	return b.String()
+}
+
+func markdownNode(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return escapeMarkdown(spaceRuns.ReplaceAllString(n.Data, " "))
+	case html.ElementNode:
+	default:
+		return markdownChildren(n)
+	}
+
+	block := func(s string) string {
+		if s = strings.TrimSpace(s); s == "" {
+			return ""
+		}
+		return "\n\n" + s + "\n\n"
+	}
+	inline := func(mark, s string) string {
+		if strings.TrimSpace(s) == "" {
+			return s
+		}
+		return mark + strings.TrimSpace(s) + mark
+	}
+
+	switch n.DataAtom {
+	case atom.Script, atom.Style, atom.Iframe, atom.Video, atom.Audio:
+		return ""
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		// Article headings nest under the "### Content" section
+		level := int(n.Data[1]-'0') + 3
+		if level > 6 {
+			level = 6
+		}
+		text := strings.TrimSpace(markdownChildren(n))
+		if text == "" {
+			return ""
+		}
+		return "\n\n" + strings.Repeat("#", level) + " " + text + "\n\n"
+	case atom.P, atom.Div, atom.Section, atom.Article, atom.Header, atom.Footer, atom.Figure, atom.Main, atom.Aside:
+		return block(markdownChildren(n))
+	case atom.Figcaption:
+		return block(inline("*", markdownChildren(n)))
+	case atom.Br:
+		return "  \n"
+	case atom.Hr:
+		return "\n\n---\n\n"
+	case atom.Strong, atom.B:
+		return inline("**", markdownChildren(n))
+	case atom.Em, atom.I:
+		return inline("*", markdownChildren(n))
+	case atom.Del, atom.S:
+		return inline("~~", markdownChildren(n))
+	case atom.Code:
+		return "`" + strings.ReplaceAll(textContent(n), "`", "'") + "`"
+	case atom.Pre:
+		return "\n\n```\n" + strings.Trim(textContent(n), "\n") + "\n```\n\n"
+	case atom.A:
+		text := strings.TrimSpace(markdownChildren(n))
+		href := attr(n, "href")
+		if href == "" || strings.HasPrefix(href, "#") {
+			return text
+		}
+		if text == "" {
+			text = escapeMarkdown(href)
+		}
+		return "[" + text + "](" + markdownURL(href) + ")"
+	case atom.Img:
+		src := attr(n, "src")
+		if src == "" {
+			return ""
+		}
+		return "![" + escapeMarkdown(attr(n, "alt")) + "](" + markdownURL(src) + ")"
+	case atom.Blockquote:
+		inner := strings.TrimSpace(blankLines.ReplaceAllString(markdownChildren(n), "\n\n"))
+		if inner == "" {
+			return ""
+		}
+		return "\n\n" + quoteMarkdown(inner) + "\n\n"
+	case atom.Ul, atom.Ol:
+		return "\n\n" + markdownList(n) + "\n\n"
+	case atom.Table:
+		return "\n\n" + markdownTable(n) + "\n\n"
+	default:
+		return markdownChildren(n)
+	}
+}
+
+// markdownList renders the items of a ul or ol element, indenting nested
+// blocks under their item.
+func markdownList(n *html.Node) string {
+	var items []string
+	index := 1
+	if start, err := strconv.Atoi(attr(n, "start")); err == nil {
+		index = start
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode || c.DataAtom != atom.Li {
+			continue
+		}
+		marker := "- "
+		if n.DataAtom == atom.Ol {
+			marker = strconv.Itoa(index) + ". "
+			index++
+		}
+		body := strings.TrimSpace(blankLines.ReplaceAllString(markdownChildren(c), "\n\n"))
+		indent := strings.Repeat(" ", len(marker))
+		lines := strings.Split(body, "\n")
+		for i := 1; i < len(lines); i++ {
+			if lines[i] != "" {
+				lines[i] = indent + lines[i]
+			}
+		}
+		items = append(items, marker+strings.Join(lines, "\n"))
+	}
+	return strings.Join(items, "\n")
+}
+
+// markdownTable renders a table as a pipe table, the first row as header.
+func markdownTable(n *html.Node) string {
+	var rows [][]string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+			if c.DataAtom != atom.Tr {
+				walk(c)
+				continue
+			}
+			var cells []string
+			for cell := c.FirstChild; cell != nil; cell = cell.NextSibling {
+				if cell.Type == html.ElementNode && (cell.DataAtom == atom.Td || cell.DataAtom == atom.Th) {
+					text := spaceRuns.ReplaceAllString(markdownChildren(cell), " ")
+					cells = append(cells, strings.ReplaceAll(strings.TrimSpace(text), "|", `\|`))
+				}
+			}
+			rows = append(rows, cells)
+		}
+	}
+	walk(n)
+	if len(rows) == 0 {
+		return ""
+	}
+
+	width := 0
+	for _, row := range rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+	if width == 0 {
+		return ""
+	}
+	line := func(cells []string) string {
+		for len(cells) < width {
+			cells = append(cells, "")
+		}
+		return "| " + strings.Join(cells, " | ") + " |"
+	}
+
+	out := []string{line(rows[0]), line(strings.Split(strings.Repeat("---,", width-1)+"---", ","))}
+	for _, row := range rows[1:] {
+		out = append(out, line(row))
+	}
+	return strings.Join(out, "\n")
+}
+
+// markdownURL makes a URL safe inside a Markdown link destination.
+func markdownURL(u string) string {
+	return strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29").Replace(u)
+}
+
+// textContent returns the concatenated text of a node.
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		b.WriteString(textContent(c))
+	}
+	return b.String()
+}
+
+// attr returns the value of an attribute of a node.
+func attr(n *html.Node, name string) string {
+	for _, a := range n.Attr {
+		if a.Key == name {
+			return a.Val
+		}
+	}
+	return ""
+}
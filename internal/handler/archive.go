@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/michael/flowreader/internal/domain"
+	"github.com/michael/flowreader/internal/service"
+)
+
+// ArchiveHandler handles article archive snapshot HTTP requests.
+type ArchiveHandler struct {
+	archiveService *service.ArchiveService
+	authService    *service.AuthService
+}
+
+// NewArchiveHandler creates a new archive handler.
+func NewArchiveHandler(archiveService *service.ArchiveService, authService *service.AuthService) *ArchiveHandler {
+	return &ArchiveHandler{
+		archiveService: archiveService,
+		authService:    authService,
+	}
+}
+
+// getUserFromRequest extracts the authenticated user from the request.
+func (h *ArchiveHandler) getUserFromRequest(r *http.Request) (uuid.UUID, error) {
+	cookie, err := r.Cookie("session_id")
+	if err != nil {
+		return uuid.Nil, errors.New("not authenticated")
+	}
+
+	user, err := h.authService.GetUserByToken(cookie.Value)
+	if err != nil || user == nil {
+		return uuid.Nil, errors.New("invalid session")
+	}
+
+	return user.ID, nil
+}
+
+// respondArchiveError maps archive service errors to HTTP responses.
+func respondArchiveError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrArticleNotFound):
+		respondError(w, http.StatusNotFound, "Article not found")
+	case errors.Is(err, service.ErrArchiveNotFound):
+		respondError(w, http.StatusNotFound, "Article is not archived")
+	case errors.Is(err, service.ErrUnauthorized):
+		respondError(w, http.StatusForbidden, "Access denied")
+	default:
+		respondError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// archiveDocument renders an archive as a standalone page.
+var archiveDocument = template.Must(template.New("archive").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { max-width: 42rem; margin: 2rem auto; padding: 0 1rem; font: 1.05rem/1.6 Georgia, serif; color: #222; }
+header { border-bottom: 1px solid #ddd; margin-bottom: 1.5rem; }
+header p { color: #666; font-size: 0.9rem; }
+img, video { max-width: 100%; height: auto; }
+pre { overflow-x: auto; }
+</style>
+</head>
+<body>
+<header>
+<h1>{{.Title}}</h1>
+<p>{{with .Author}}{{.}} · {{end}}{{with .PublishedAt}}{{.Format "2 January 2006"}} · {{end}}{{with .URL}}<a href="{{.}}">{{.}}</a> · {{end}}archived {{.ArchivedAt.Format "2 January 2006"}}</p>
+</header>
+<article>{{.Content}}</article>
+</body>
+</html>
+`))
+
+// archivePage is the data of archiveDocument. Content is the archive's
+// sanitized HTML.
+type archivePage struct {
+	*domain.ArticleArchive
+	Content template.HTML
+}
+
+// Get handles GET /api/v1/articles/{id}/archive
+//
+// With format=html the snapshot is served as a standalone page whose content
+// security policy only allows the inlined images.
+func (h *ArchiveHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	articleID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid article ID")
+		return
+	}
+
+	archive, err := h.archiveService.GetArchive(articleID, userID)
+	if err != nil {
+		respondArchiveError(w, err, "Failed to get archive")
+		return
+	}
+
+	if r.URL.Query().Get("format") != "html" {
+		respondJSON(w, http.StatusOK, archive)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; img-src data:; style-src 'unsafe-inline'")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if err := archiveDocument.Execute(w, archivePage{
+		ArticleArchive: archive,
+		Content:        template.HTML(archive.Content),
+	}); err != nil {
+		log.Printf("Rendering archive of article %s: %v", articleID, err)
+	}
+}
+
+// Create handles POST /api/v1/articles/{id}/archive
+//
+// It captures the snapshot now, replacing any previous one. Favorites are
+// archived automatically in the background.
+func (h *ArchiveHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	articleID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid article ID")
+		return
+	}
+
+	// Keep archiving if the client goes away: the snapshot is still useful.
+	// Fetching the page and its images can outlast the write timeout.
+	clearWriteDeadline(w)
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), 3*time.Minute)
+	defer cancel()
+
+	archive, err := h.archiveService.Archive(ctx, articleID, userID)
+	if err != nil {
+		respondArchiveError(w, err, "Failed to archive article")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, archive)
+}
+
+// Delete handles DELETE /api/v1/articles/{id}/archive
+func (h *ArchiveHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	articleID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid article ID")
+		return
+	}
+
+	if err := h.archiveService.DeleteArchive(articleID, userID); err != nil {
+		respondArchiveError(w, err, "Failed to delete archive")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Archive deleted"})
+}
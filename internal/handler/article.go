@@ -1,13 +1,18 @@
 package handler
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/michael/flowreader/internal/domain"
+	"github.com/michael/flowreader/internal/search"
 	"github.com/michael/flowreader/internal/service"
 	"github.com/michael/flowreader/internal/utils"
 	"github.com/michael/flowreader/internal/ws"
@@ -15,33 +20,43 @@ import (
 
 // ArticleHandler handles article-related HTTP requests.
 type ArticleHandler struct {
-	articleRepo domain.ArticleRepository
-	feedService *service.FeedService
-	authService *service.AuthService
-	aiService   *service.AIService
-	sanitizer   *utils.ContentSanitizer
-	extractor   *utils.ContentExtractor
-	hub         *ws.Hub
+	articleRepo   domain.ArticleRepository
+	feedService   *service.FeedService
+	authService   *service.AuthService
+	aiService     *service.AIService
+	readerService *service.ReaderService
+	tagService    *service.TagService
+	readLaterRepo domain.ReadLaterRepository
+	savedSearches *service.SavedSearchService
+	sanitizer     *utils.ContentSanitizer
+	imageProxy    *service.ImageProxyService
+	hub           *ws.Hub
 }
 
 // NewArticleHandler creates a new article handler.
-func NewArticleHandler(articleRepo domain.ArticleRepository, feedService *service.FeedService, authService *service.AuthService, aiService *service.AIService, hub *ws.Hub) *ArticleHandler {
+func NewArticleHandler(articleRepo domain.ArticleRepository, feedService *service.FeedService, authService *service.AuthService, aiService *service.AIService, readerService *service.ReaderService, tagService *service.TagService, readLaterRepo domain.ReadLaterRepository, savedSearches *service.SavedSearchService, sanitizer *utils.ContentSanitizer, imageProxy *service.ImageProxyService, hub *ws.Hub) *ArticleHandler {
 	return &ArticleHandler{
-		articleRepo: articleRepo,
-		feedService: feedService,
-		authService: authService,
-		aiService:   aiService,
-		sanitizer:   utils.NewContentSanitizer(),
-		extractor:   utils.NewContentExtractor(),
-		hub:         hub,
+		articleRepo:   articleRepo,
+		feedService:   feedService,
+		authService:   authService,
+		aiService:     aiService,
+		readerService: readerService,
+		tagService:    tagService,
+		readLaterRepo: readLaterRepo,
+		savedSearches: savedSearches,
+		sanitizer:     sanitizer,
+		imageProxy:    imageProxy,
+		hub:           hub,
 	}
 }
 
 // sanitizeArticle cleans the user-facing HTML fields of a single article to
-// prevent stored XSS from malicious feeds. AISummary is rendered as plain text
-// by the client, so only Content and Summary need sanitization.
+// prevent stored XSS from malicious feeds. Articles are sanitized at ingest,
+// so this only does work for rows the Resanitizer has not caught up with yet.
+// AISummary is rendered as plain text by the client, so only Content and
+// Summary need sanitization.
 func (h *ArticleHandler) sanitizeArticle(a *domain.Article) {
-	if a == nil {
+	if a == nil || a.SanitizerVersion == h.sanitizer.Version() {
 		return
 	}
 	if a.Content != "" {
@@ -52,66 +67,105 @@ func (h *ArticleHandler) sanitizeArticle(a *domain.Article) {
 	}
 }
 
-// sanitizeArticles cleans a slice of articles in place.
-func (h *ArticleHandler) sanitizeArticles(articles []*domain.Article) {
+// prepareArticle sanitizes an article for output and, when the user opted
+// in, routes its images through the image proxy.
+func (h *ArticleHandler) prepareArticle(user *domain.User, a *domain.Article) {
+	h.sanitizeArticle(a)
+	if user.ProxyImages && h.imageProxy != nil {
+		h.imageProxy.RewriteArticle(a)
+	}
+}
+
+// prepareArticles applies prepareArticle to a slice of articles in place.
+func (h *ArticleHandler) prepareArticles(user *domain.User, articles []*domain.Article) {
 	for _, a := range articles {
-		h.sanitizeArticle(a)
+		h.prepareArticle(user, a)
 	}
 }
 
-// getUserFromRequest extracts the authenticated user from the request.
-func (h *ArticleHandler) getUserFromRequest(r *http.Request) (uuid.UUID, error) {
+// getCurrentUser returns the authenticated user for the request.
+func (h *ArticleHandler) getCurrentUser(r *http.Request) (*domain.User, error) {
 	cookie, err := r.Cookie("session_id")
 	if err != nil {
-		return uuid.Nil, err
+		return nil, err
 	}
 
 	user, err := h.authService.GetUserByToken(cookie.Value)
-	if err != nil || user == nil {
-		return uuid.Nil, err
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("invalid session")
 	}
 
+	return user, nil
+}
+
+// getUserFromRequest extracts the authenticated user ID from the request.
+func (h *ArticleHandler) getUserFromRequest(r *http.Request) (uuid.UUID, error) {
+	user, err := h.getCurrentUser(r)
+	if err != nil {
+		return uuid.Nil, err
+	}
 	return user.ID, nil
 }
 
 // List handles GET /api/v1/articles
+//
+// Filters (all optional, combined with AND): feed_id (repeatable or comma
+// separated), category, read, unread=true, favorite, author, since, until,
+// has_image, q and sort (newest, oldest, relevance). The total number of
+// matching articles is returned in X-Total-Count and in the cursor envelope.
 func (h *ArticleHandler) List(w http.ResponseWriter, r *http.Request) {
-	userID, err := h.getUserFromRequest(r)
+	user, err := h.getCurrentUser(r)
 	if err != nil {
 		respondError(w, http.StatusUnauthorized, "Not authenticated")
 		return
 	}
 
 	// Parse query parameters
-	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
-	if limit <= 0 || limit > 100 {
-		limit = 50
+	page, cursorMode, err := parsePage(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid cursor")
+		return
 	}
 
-	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
-	if offset < 0 {
-		offset = 0
+	filter, err := parseArticleFilter(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
 	}
+	filter.UserID = user.ID
 
-	unreadOnly := r.URL.Query().Get("unread") == "true"
+	if filter, err = h.applySavedSearch(w, r, user.ID, filter); err != nil {
+		return
+	}
 
-	articles, err := h.articleRepo.GetByUserID(userID, limit, offset, unreadOnly)
+	articles, err := h.articleRepo.Query(filter, page)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to get articles")
 		return
 	}
 
-	h.sanitizeArticles(articles)
-	respondJSON(w, http.StatusOK, articles)
+	total, err := h.articleRepo.Count(filter)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to count articles")
+		return
+	}
+
+	h.prepareArticles(user, articles)
+	ranked := filter.Sort == domain.SortRelevance && filter.Query != ""
+	respondArticles(w, articles, page, cursorMode, ranked, &total)
 }
 
 // ListByFeed handles GET /api/v1/feeds/{id}/articles
 func (h *ArticleHandler) ListByFeed(w http.ResponseWriter, r *http.Request) {
-	userID, err := h.getUserFromRequest(r)
+	user, err := h.getCurrentUser(r)
 	if err != nil {
 		respondError(w, http.StatusUnauthorized, "Not authenticated")
 		return
 	}
+	userID := user.ID
 
 	feedID, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
@@ -127,33 +181,30 @@ func (h *ArticleHandler) ListByFeed(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Parse query parameters
-	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
-	if limit <= 0 || limit > 100 {
-		limit = 50
-	}
-
-	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
-	if offset < 0 {
-		offset = 0
+	page, cursorMode, err := parsePage(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid cursor")
+		return
 	}
 
-	articles, err := h.articleRepo.GetByFeedID(feedID, limit, offset)
+	articles, err := h.articleRepo.GetByFeedID(feedID, page)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to get articles")
 		return
 	}
 
-	h.sanitizeArticles(articles)
-	respondJSON(w, http.StatusOK, articles)
+	h.prepareArticles(user, articles)
+	respondArticles(w, articles, page, cursorMode, false, nil)
 }
 
 // Get handles GET /api/v1/articles/{id}
 func (h *ArticleHandler) Get(w http.ResponseWriter, r *http.Request) {
-	userID, err := h.getUserFromRequest(r)
+	user, err := h.getCurrentUser(r)
 	if err != nil {
 		respondError(w, http.StatusUnauthorized, "Not authenticated")
 		return
 	}
+	userID := user.ID
 
 	articleID, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
@@ -175,7 +226,7 @@ func (h *ArticleHandler) Get(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Sanitize user-facing HTML content (defense against stored XSS).
-	h.sanitizeArticle(article)
+	h.prepareArticle(user, article)
 
 	respondJSON(w, http.StatusOK, article)
 }
@@ -267,7 +318,30 @@ func (h *ArticleHandler) MarkUnread(w http.ResponseWriter, r *http.Request) {
 }
 
 // ToggleFavorite handles POST /api/v1/articles/{id}/favorite
+//
+// Kept for older clients; prefer the idempotent PUT/DELETE variants.
 func (h *ArticleHandler) ToggleFavorite(w http.ResponseWriter, r *http.Request) {
+	h.updateFavorite(w, r, h.articleRepo.ToggleFavorite)
+}
+
+// Favorite handles PUT /api/v1/articles/{id}/favorite
+func (h *ArticleHandler) Favorite(w http.ResponseWriter, r *http.Request) {
+	h.updateFavorite(w, r, func(id uuid.UUID) (bool, *time.Time, error) {
+		return h.articleRepo.SetFavorite(id, true)
+	})
+}
+
+// Unfavorite handles DELETE /api/v1/articles/{id}/favorite
+func (h *ArticleHandler) Unfavorite(w http.ResponseWriter, r *http.Request) {
+	h.updateFavorite(w, r, func(id uuid.UUID) (bool, *time.Time, error) {
+		return h.articleRepo.SetFavorite(id, false)
+	})
+}
+
+// updateFavorite checks ownership, applies a favorite update and reports the
+// state returned by the database, so that concurrent devices and retried
+// requests always see and broadcast the actual value.
+func (h *ArticleHandler) updateFavorite(w http.ResponseWriter, r *http.Request, update func(uuid.UUID) (bool, *time.Time, error)) {
 	userID, err := h.getUserFromRequest(r)
 	if err != nil {
 		respondError(w, http.StatusUnauthorized, "Not authenticated")
@@ -293,23 +367,196 @@ func (h *ArticleHandler) ToggleFavorite(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if err := h.articleRepo.ToggleFavorite(articleID); err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to toggle favorite")
+	isFavorite, favoritedAt, err := update(articleID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update favorite")
 		return
 	}
 
 	// Broadcast update
 	if h.hub != nil {
-		h.hub.Broadcast("article_updated", map[string]interface{}{
-			"id":          articleID,
-			"is_favorite": !article.IsFavorite,
+		h.hub.BroadcastToUser(userID, "article_updated", map[string]interface{}{
+			"id":           articleID,
+			"is_favorite":  isFavorite,
+			"favorited_at": favoritedAt,
 		})
 	}
 
-	respondJSON(w, http.StatusOK, map[string]bool{"is_favorite": !article.IsFavorite})
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"is_favorite":  isFavorite,
+		"favorited_at": favoritedAt,
+	})
+}
+
+// maxBatchArticles caps the number of articles in one batch request.
+const maxBatchArticles = 1000
+
+// BatchRequest represents the request body for a batch article operation.
+type BatchRequest struct {
+	IDs       []uuid.UUID           `json:"ids"`
+	Operation domain.ArticleBatchOp `json:"operation"`
+	Tags      []string              `json:"tags,omitempty"` // tag / untag only
+}
+
+// BatchResponse reports which articles a batch operation updated. IDs that
+// do not exist (e.g. already cleaned up) or are not owned are listed as not
+// found so that offline replays can drop them.
+type BatchResponse struct {
+	Operation domain.ArticleBatchOp `json:"operation"`
+	Updated   []uuid.UUID           `json:"updated"`
+	NotFound  []uuid.UUID           `json:"not_found,omitempty"`
+}
+
+// Batch handles POST /api/v1/articles/batch
+func (h *ArticleHandler) Batch(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	var req BatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if len(req.IDs) == 0 {
+		respondError(w, http.StatusBadRequest, "No article IDs provided")
+		return
+	}
+	if len(req.IDs) > maxBatchArticles {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("Too many articles (max %d)", maxBatchArticles))
+		return
+	}
+
+	var updated []uuid.UUID
+	switch req.Operation {
+	case domain.BatchRead, domain.BatchUnread, domain.BatchFavorite, domain.BatchUnfavorite:
+		updated, err = h.articleRepo.BatchUpdate(userID, req.IDs, req.Operation)
+	case domain.BatchTag, domain.BatchUntag:
+		if len(req.Tags) == 0 {
+			respondError(w, http.StatusBadRequest, "No tags provided")
+			return
+		}
+		if req.Operation == domain.BatchTag {
+			updated, err = h.tagService.TagArticles(userID, req.IDs, req.Tags)
+		} else {
+			updated, err = h.tagService.UntagArticles(userID, req.IDs, req.Tags)
+		}
+	default:
+		respondError(w, http.StatusBadRequest, "Unsupported operation")
+		return
+	}
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidTagName) {
+			respondError(w, http.StatusBadRequest, "Invalid tag name")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to update articles")
+		return
+	}
+
+	resp := BatchResponse{Operation: req.Operation, Updated: updated}
+	if resp.Updated == nil {
+		resp.Updated = []uuid.UUID{}
+	}
+	seen := make(map[uuid.UUID]bool, len(updated))
+	for _, id := range updated {
+		seen[id] = true
+	}
+	for _, id := range req.IDs {
+		if !seen[id] {
+			seen[id] = true
+			resp.NotFound = append(resp.NotFound, id)
+		}
+	}
+
+	// One aggregated event for the whole batch
+	if h.hub != nil && len(updated) > 0 {
+		payload := map[string]interface{}{"ids": updated}
+		switch req.Operation {
+		case domain.BatchRead, domain.BatchUnread:
+			payload["is_read"] = req.Operation == domain.BatchRead
+		case domain.BatchFavorite, domain.BatchUnfavorite:
+			payload["is_favorite"] = req.Operation == domain.BatchFavorite
+		case domain.BatchTag:
+			payload["tags_added"] = req.Tags
+		case domain.BatchUntag:
+			payload["tags_removed"] = req.Tags
+		}
+		h.hub.BroadcastToUser(userID, "articles_updated", payload)
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// SetTagsRequest represents the request body for replacing an article's tags.
+type SetTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// SetTags handles PUT /api/v1/articles/{id}/tags
+func (h *ArticleHandler) SetTags(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	articleID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid article ID")
+		return
+	}
+
+	var req SetTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	article, err := h.articleRepo.GetByID(articleID)
+	if err != nil || article == nil {
+		respondError(w, http.StatusNotFound, "Article not found")
+		return
+	}
+
+	// Verify feed ownership
+	_, err = h.feedService.GetFeed(article.FeedID, userID)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	tags, err := h.tagService.SetArticleTags(userID, articleID, req.Tags)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidTagName) {
+			respondError(w, http.StatusBadRequest, "Invalid tag name")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to set tags")
+		return
+	}
+
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+
+	if h.hub != nil {
+		h.hub.BroadcastToUser(userID, "article_updated", map[string]interface{}{
+			"id":   articleID,
+			"tags": names,
+		})
+	}
+
+	respondJSON(w, http.StatusOK, map[string][]string{"tags": names})
 }
 
 // MarkAllRead handles POST /api/v1/feeds/{id}/read-all
+//
+// Accepts the same scope parameters as MarkAllReadGlobal.
 func (h *ArticleHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
 	userID, err := h.getUserFromRequest(r)
 	if err != nil {
@@ -330,15 +577,26 @@ func (h *ArticleHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.articleRepo.MarkAllAsRead(feedID); err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to mark all as read")
+	filter, maxArticleID, err := parseMarkReadScope(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	filter.UserID = userID
+	filter.FeedIDs = []uuid.UUID{feedID}
 
-	respondJSON(w, http.StatusOK, map[string]string{"message": "All articles marked as read"})
+	if filter, err = h.applyMaxArticle(w, userID, maxArticleID, filter); err != nil {
+		return
+	}
+
+	h.markAllRead(w, userID, filter)
 }
 
 // MarkAllReadGlobal handles POST /api/v1/articles/read-all
+//
+// Optional query parameters bound what gets marked: before (RFC 3339, only
+// articles fetched up to then) or max_article_id (only articles fetched up
+// to that one), plus any list filter such as category or q.
 func (h *ArticleHandler) MarkAllReadGlobal(w http.ResponseWriter, r *http.Request) {
 	userID, err := h.getUserFromRequest(r)
 	if err != nil {
@@ -346,17 +604,152 @@ func (h *ArticleHandler) MarkAllReadGlobal(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	if err := h.articleRepo.MarkAllAsReadGlobal(userID); err != nil {
+	filter, maxArticleID, err := parseMarkReadScope(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.UserID = userID
+
+	if filter, err = h.applyMaxArticle(w, userID, maxArticleID, filter); err != nil {
+		return
+	}
+	if filter, err = h.applySavedSearch(w, r, userID, filter); err != nil {
+		return
+	}
+
+	h.markAllRead(w, userID, filter)
+}
+
+// applyMaxArticle bounds the filter to the articles ingested up to the
+// user's article maxArticleID, if set. On error the response has been
+// written.
+func (h *ArticleHandler) applyMaxArticle(w http.ResponseWriter, userID, maxArticleID uuid.UUID, filter domain.ArticleFilter) (domain.ArticleFilter, error) {
+	if maxArticleID == uuid.Nil {
+		return filter, nil
+	}
+
+	article, err := h.articleRepo.GetByID(maxArticleID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get article")
+		return filter, err
+	}
+	if article == nil {
+		respondError(w, http.StatusNotFound, "Article not found")
+		return filter, errors.New("max article not found")
+	}
+	if _, err := h.feedService.GetFeed(article.FeedID, userID); err != nil {
+		// Someone else's article: not found, like a missing one
+		respondError(w, http.StatusNotFound, "Article not found")
+		return filter, err
+	}
+
+	if filter.CreatedBefore == nil || article.CreatedAt.Before(*filter.CreatedBefore) {
+		filter.CreatedBefore = &article.CreatedAt
+	}
+	return filter, nil
+}
+
+// applySavedSearch replaces the filter with the saved search named by the
+// saved_search query parameter, if any. The read state, ordering and
+// high-water marks of the request still apply. On error the response has
+// been written.
+func (h *ArticleHandler) applySavedSearch(w http.ResponseWriter, r *http.Request, userID uuid.UUID, filter domain.ArticleFilter) (domain.ArticleFilter, error) {
+	raw := r.URL.Query().Get("saved_search")
+	if raw == "" {
+		return filter, nil
+	}
+
+	searchID, err := uuid.Parse(raw)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid saved_search")
+		return filter, err
+	}
+
+	// Keep the saved search's own ordering unless the client asked for one
+	if r.URL.Query().Get("sort") == "" {
+		filter.Sort = ""
+	}
+
+	filter, err = h.savedSearches.Scope(searchID, userID, filter)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrSavedSearchNotFound):
+			respondError(w, http.StatusNotFound, "Saved search not found")
+		case errors.Is(err, service.ErrUnauthorized):
+			respondError(w, http.StatusForbidden, "Access denied")
+		default:
+			respondError(w, http.StatusInternalServerError, "Failed to get saved search")
+		}
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+// markAllRead marks the matching articles as read, pushes the new unread
+// counters to the user's clients and writes the response.
+func (h *ArticleHandler) markAllRead(w http.ResponseWriter, userID uuid.UUID, filter domain.ArticleFilter) {
+	marked, err := h.articleRepo.MarkAsReadMatching(filter)
+	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to mark all as read")
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]string{"message": "All articles marked as read"})
+	if h.hub != nil && marked > 0 {
+		counts, err := h.articleRepo.UnreadCounts(userID)
+		if err == nil {
+			total := 0
+			for _, n := range counts {
+				total += n
+			}
+			payload := map[string]interface{}{
+				"total": total,
+				"feeds": counts,
+			}
+			if searchCounts, err := h.savedSearches.UnreadCounts(userID); err == nil {
+				payload["saved_searches"] = searchCounts
+			}
+			h.hub.BroadcastToUser(userID, "unread_counts", payload)
+		}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "All articles marked as read",
+		"marked":  marked,
+	})
 }
 
 // GetFavorites handles GET /api/v1/articles/favorites
 func (h *ArticleHandler) GetFavorites(w http.ResponseWriter, r *http.Request) {
-	userID, err := h.getUserFromRequest(r)
+	user, err := h.getCurrentUser(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+	userID := user.ID
+
+	page, cursorMode, err := parsePage(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid cursor")
+		return
+	}
+
+	articles, err := h.articleRepo.GetFavorites(userID, page)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get favorites")
+		return
+	}
+
+	h.prepareArticles(user, articles)
+	respondArticles(w, articles, page, cursorMode, false, nil)
+}
+
+// Recent handles GET /api/v1/articles/recent
+//
+// Lists the articles the user read or opened, most recent first.
+func (h *ArticleHandler) Recent(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getCurrentUser(r)
 	if err != nil {
 		respondError(w, http.StatusUnauthorized, "Not authenticated")
 		return
@@ -372,48 +765,110 @@ func (h *ArticleHandler) GetFavorites(w http.ResponseWriter, r *http.Request) {
 		offset = 0
 	}
 
-	articles, err := h.articleRepo.GetFavorites(userID, limit, offset)
+	articles, err := h.articleRepo.RecentlyRead(user.ID, limit, offset)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to get favorites")
+		respondError(w, http.StatusInternalServerError, "Failed to get recently read articles")
 		return
 	}
+	if articles == nil {
+		articles = []*domain.Article{}
+	}
 
-	h.sanitizeArticles(articles)
+	h.prepareArticles(user, articles)
 	respondJSON(w, http.StatusOK, articles)
 }
 
 // Search handles GET /api/v1/articles/search
+//
+// Besides full-text terms the query accepts the operators feed:, author:,
+// tag:, is:unread|read|starred, has:image, before: and after: (YYYY-MM-DD).
+// Values with spaces are quoted (feed:"Hacker News") and all but the date
+// operators can be negated with a leading "-". Syntax errors are reported as
+// 400 with a details object locating the offending token.
+//
+// With mode=fuzzy the terms also match article titles by trigram
+// similarity, which finds typos and partial words.
+//
+// With view=compact the hits are returned in the lightweight search result
+// shape (highlighted title and snippet, rank, no bodies) instead of full
+// articles.
 func (h *ArticleHandler) Search(w http.ResponseWriter, r *http.Request) {
-	userID, err := h.getUserFromRequest(r)
+	user, err := h.getCurrentUser(r)
 	if err != nil {
 		respondError(w, http.StatusUnauthorized, "Not authenticated")
 		return
 	}
+	userID := user.ID
+
+	page, cursorMode, err := parsePage(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid cursor")
+		return
+	}
+
+	var fuzzy bool
+	switch r.URL.Query().Get("mode") {
+	case "", "fulltext":
+	case "fuzzy":
+		fuzzy = true
+	default:
+		respondError(w, http.StatusBadRequest, "Invalid search mode")
+		return
+	}
 
 	query := r.URL.Query().Get("q")
-	if query == "" {
-		respondJSON(w, http.StatusOK, []*domain.Article{})
+	compact := r.URL.Query().Get("view") == "compact"
+	if strings.TrimSpace(query) == "" {
+		if compact {
+			respondSearchResults(w, nil, page, cursorMode)
+			return
+		}
+		respondArticles(w, nil, page, cursorMode, true, nil)
 		return
 	}
 
-	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
-	if limit <= 0 || limit > 100 {
-		limit = 50
+	parsed, err := search.Parse(query)
+	if err != nil {
+		var perr *search.Error
+		if errors.As(err, &perr) {
+			respondJSON(w, http.StatusBadRequest, map[string]interface{}{
+				"error":   perr.Message,
+				"details": perr,
+			})
+			return
+		}
+		respondError(w, http.StatusBadRequest, "Invalid search query")
+		return
 	}
 
-	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
-	if offset < 0 {
-		offset = 0
+	filter := domain.ArticleFilter{UserID: userID, Sort: domain.SortRelevance, Fuzzy: fuzzy}
+	parsed.Apply(&filter)
+
+	if compact {
+		results, err := h.articleRepo.SearchResults(filter, page)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to search articles")
+			return
+		}
+		if user.ProxyImages && h.imageProxy != nil {
+			for _, res := range results {
+				if res.ImageURL != "" {
+					res.ImageURL = h.imageProxy.URL(res.ImageURL)
+				}
+			}
+		}
+		respondSearchResults(w, results, page, cursorMode)
+		return
 	}
 
-	articles, err := h.articleRepo.Search(userID, query, limit, offset)
+	articles, err := h.articleRepo.Query(filter, page)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to search articles")
 		return
 	}
 
-	h.sanitizeArticles(articles)
-	respondJSON(w, http.StatusOK, articles)
+	h.prepareArticles(user, articles)
+	respondArticles(w, articles, page, cursorMode, true, nil)
 }
 
 // Summarize handles POST /api/v1/articles/{id}/summarize
@@ -455,11 +910,15 @@ func (h *ArticleHandler) Summarize(w http.ResponseWriter, r *http.Request) {
 		content = article.Summary
 	}
 
-	// Try to extract full content from URL if available
+	// Use the full page content if available (cached by the reader view)
 	if article.URL != "" {
-		fullContent, err := h.extractor.Extract(r.Context(), article.URL)
-		if err == nil && len(fullContent) > len(content) {
-			content = "--- CONTENU COMPLET EXTRAIT DU SITE WEB ---\n" + fullContent
+		fullText, err := h.readerService.GetFullText(r.Context(), article, false)
+		if err == nil {
+			// Limit to 10000 bytes to avoid huge payloads to AI
+			fullContent := utils.TruncateUTF8(fullText.Text, 10000)
+			if len(fullContent) > len(content) {
+				content = "--- CONTENU COMPLET EXTRAIT DU SITE WEB ---\n" + fullContent
+			}
 		}
 	}
 
@@ -489,3 +948,52 @@ func (h *ArticleHandler) Summarize(w http.ResponseWriter, r *http.Request) {
 
 	respondJSON(w, http.StatusOK, map[string]string{"summary": summary})
 }
+
+// FullText handles GET /api/v1/articles/{id}/fulltext
+func (h *ArticleHandler) FullText(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getCurrentUser(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	articleID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid article ID")
+		return
+	}
+
+	article, err := h.articleRepo.GetByID(articleID)
+	if err != nil || article == nil {
+		respondError(w, http.StatusNotFound, "Article not found")
+		return
+	}
+
+	// Verify feed ownership
+	_, err = h.feedService.GetFeed(article.FeedID, user.ID)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	refresh := r.URL.Query().Get("refresh") == "true"
+
+	fullText, err := h.readerService.GetFullText(r.Context(), article, refresh)
+	if err != nil {
+		if errors.Is(err, service.ErrNoArticleURL) {
+			respondError(w, http.StatusUnprocessableEntity, "Article has no URL to extract from")
+			return
+		}
+		respondError(w, http.StatusBadGateway, "Failed to extract full text")
+		return
+	}
+
+	if user.ProxyImages && h.imageProxy != nil {
+		fullText.Content = h.imageProxy.RewriteHTML(fullText.Content)
+		if fullText.LeadImage != "" {
+			fullText.LeadImage = h.imageProxy.URL(fullText.LeadImage)
+		}
+	}
+
+	respondJSON(w, http.StatusOK, fullText)
+}
@@ -142,9 +142,47 @@ func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
 	}
 
 	respondJSON(w, http.StatusOK, service.UserInfo{
-		ID:      user.ID,
-		Email:   user.Email,
-		IsAdmin: user.IsAdmin,
+		ID:          user.ID,
+		Email:       user.Email,
+		IsAdmin:     user.IsAdmin,
+		ProxyImages: user.ProxyImages,
+	})
+}
+
+// UpdatePreferences handles PATCH /api/v1/users/me/preferences
+func (h *AuthHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	cookie, err := r.Cookie("session_id")
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	user, err := h.authService.GetUserByToken(cookie.Value)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get user")
+		return
+	}
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Session expired")
+		return
+	}
+
+	var req service.PreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.authService.UpdatePreferences(user, req); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update preferences")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, service.UserInfo{
+		ID:          user.ID,
+		Email:       user.Email,
+		IsAdmin:     user.IsAdmin,
+		ProxyImages: user.ProxyImages,
 	})
 }
 
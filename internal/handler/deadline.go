@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"time"
+)
+
+// clearWriteDeadline lifts the server's write timeout for a response that
+// takes longer to produce, such as an archive or an export. Such routes are
+// registered without the router's request timeout and bound their work
+// themselves.
+func clearWriteDeadline(w http.ResponseWriter) {
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+		log.Printf("Clearing write deadline: %v", err)
+	}
+}
@@ -0,0 +1,274 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/michael/flowreader/internal/export"
+	"github.com/michael/flowreader/internal/service"
+)
+
+// ExportHandler handles article export HTTP requests.
+type ExportHandler struct {
+	exportService *service.ExportService
+	epubService   *service.EPUBService
+	authService   *service.AuthService
+}
+
+// NewExportHandler creates a new export handler.
+func NewExportHandler(exportService *service.ExportService, epubService *service.EPUBService, authService *service.AuthService) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+		epubService:   epubService,
+		authService:   authService,
+	}
+}
+
+// getUserFromRequest extracts the authenticated user from the request.
+func (h *ExportHandler) getUserFromRequest(r *http.Request) (uuid.UUID, error) {
+	cookie, err := r.Cookie("session_id")
+	if err != nil {
+		return uuid.Nil, errors.New("not authenticated")
+	}
+
+	user, err := h.authService.GetUserByToken(cookie.Value)
+	if err != nil || user == nil {
+		return uuid.Nil, errors.New("invalid session")
+	}
+
+	return user.ID, nil
+}
+
+// respondEPUBError maps EPUB service errors to HTTP responses.
+func respondEPUBError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidEPUBRequest):
+		respondError(w, http.StatusBadRequest, "Invalid EPUB request")
+	case errors.Is(err, service.ErrEPUBEmpty):
+		respondError(w, http.StatusUnprocessableEntity, "No articles selected")
+	case errors.Is(err, service.ErrSavedSearchNotFound):
+		respondError(w, http.StatusNotFound, "Saved search not found")
+	case errors.Is(err, service.ErrEditionNotFound):
+		respondError(w, http.StatusNotFound, "Daily edition not found")
+	case errors.Is(err, service.ErrEditionGenerating):
+		respondError(w, http.StatusConflict, "Daily edition is already being generated")
+	case errors.Is(err, service.ErrEditionQueueFull):
+		respondError(w, http.StatusServiceUnavailable, "Too many daily editions queued, try again later")
+	default:
+		respondError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// Articles handles GET /api/v1/export/articles
+//
+// format is json (default), markdown, html or bookmarks. The article list
+// filters apply (favorite, tag, since, until, feed_id, q, ...); without
+// filters every article is exported. The response is streamed, so errors
+// after the first article can only truncate it.
+func (h *ExportHandler) Articles(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	format := export.FormatJSON
+	if v := r.URL.Query().Get("format"); v != "" {
+		if format, err = export.ParseFormat(v); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid format")
+			return
+		}
+	}
+
+	filter, err := parseArticleFilter(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	title := "FlowReader articles"
+	if filter.IsFavorite != nil && *filter.IsFavorite {
+		title = "FlowReader favorites"
+	}
+
+	now := time.Now()
+	writer, err := export.NewWriter(format, w, title, now)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid format")
+		return
+	}
+
+	filename := fmt.Sprintf("flowreader-articles-%s.%s", now.Format("2006-01-02"), format.Extension())
+	if format == export.FormatBookmarks {
+		filename = fmt.Sprintf("flowreader-bookmarks-%s.html", now.Format("2006-01-02"))
+	}
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	clearWriteDeadline(w)
+
+	flush := func() {
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+	}
+
+	if _, err := h.exportService.ExportArticles(r.Context(), userID, filter, writer, flush); err != nil {
+		log.Printf("Exporting articles for user %s: %v", userID, err)
+	}
+}
+
+// EPUB handles POST /api/v1/export/epub
+//
+// The body selects the articles: source is articles (article_ids), unread
+// (optional category), saved_search (saved_search_id) or read_later; limit
+// defaults to 50, at most 200. The book is streamed once the selection is
+// resolved, so errors while embedding images can only truncate it.
+func (h *ExportHandler) EPUB(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	var req service.EPUBRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	articles, err := h.epubService.Select(userID, req)
+	if err != nil {
+		respondEPUBError(w, err, "Failed to select articles")
+		return
+	}
+
+	now := time.Now()
+	w.Header().Set("Content-Type", "application/epub+zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=flowreader-%s.epub", now.Format("2006-01-02")))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	clearWriteDeadline(w)
+
+	if err := h.epubService.Write(r.Context(), w, h.epubService.Title(req, now), articles, now); err != nil {
+		log.Printf("Exporting EPUB for user %s: %v", userID, err)
+	}
+}
+
+// GetEdition handles GET /api/v1/export/epub/daily
+func (h *ExportHandler) GetEdition(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	edition, err := h.epubService.GetEdition(userID)
+	if err != nil {
+		respondEPUBError(w, err, "Failed to get daily edition")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, edition)
+}
+
+// SetEdition handles PUT /api/v1/export/epub/daily
+//
+// The body holds the settings: enabled, source (unread, saved_search or
+// read_later), category, saved_search_id, max_articles, and the hour and
+// IANA timezone the edition is generated at.
+func (h *ExportHandler) SetEdition(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	var req service.EditionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	edition, err := h.epubService.SetEdition(userID, req)
+	if err != nil {
+		respondEPUBError(w, err, "Failed to save daily edition")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, edition)
+}
+
+// DeleteEdition handles DELETE /api/v1/export/epub/daily
+func (h *ExportHandler) DeleteEdition(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	if err := h.epubService.DeleteEdition(userID); err != nil {
+		respondEPUBError(w, err, "Failed to delete daily edition")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Daily edition deleted"})
+}
+
+// GenerateEdition handles POST /api/v1/export/epub/daily/generate
+//
+// It generates the edition now instead of waiting for its hour. Embedding
+// the images can take minutes, so the edition worker generates it in the
+// background and the response is the edition with generating set; the
+// outcome shows in its generated_at, attempted_at and last_error. While an
+// edition is in flight, further requests get 409.
+func (h *ExportHandler) GenerateEdition(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	edition, err := h.epubService.RequestEdition(userID)
+	if err != nil {
+		respondEPUBError(w, err, "Failed to generate daily edition")
+		return
+	}
+
+	respondJSON(w, http.StatusAccepted, edition)
+}
+
+// DownloadEdition handles GET /api/v1/export/epub/daily/download
+func (h *ExportHandler) DownloadEdition(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	data, edition, err := h.epubService.EditionFile(userID)
+	if err != nil {
+		respondEPUBError(w, err, "Failed to get daily edition")
+		return
+	}
+
+	date := edition.UpdatedAt
+	if edition.GeneratedAt != nil {
+		date = *edition.GeneratedAt
+	}
+	if loc, err := time.LoadLocation(edition.Timezone); err == nil {
+		date = date.In(loc)
+	}
+
+	w.Header().Set("Content-Type", "application/epub+zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=flowreader-daily-%s.epub", date.Format("2006-01-02")))
+	w.Header().Set("Content-Length", fmt.Sprint(len(data)))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if _, err := w.Write(data); err != nil {
+		log.Printf("Sending daily edition to user %s: %v", userID, err)
+	}
+}
@@ -15,17 +15,19 @@ import (
 
 // FeedHandler handles feed-related HTTP requests.
 type FeedHandler struct {
-	feedService  *service.FeedService
-	fetchService *service.FetchService
-	authService  *service.AuthService
+	feedService    *service.FeedService
+	fetchService   *service.FetchService
+	faviconService *service.FaviconService
+	authService    *service.AuthService
 }
 
 // NewFeedHandler creates a new feed handler.
-func NewFeedHandler(feedService *service.FeedService, fetchService *service.FetchService, authService *service.AuthService) *FeedHandler {
+func NewFeedHandler(feedService *service.FeedService, fetchService *service.FetchService, faviconService *service.FaviconService, authService *service.AuthService) *FeedHandler {
 	return &FeedHandler{
-		feedService:  feedService,
-		fetchService: fetchService,
-		authService:  authService,
+		feedService:    feedService,
+		fetchService:   fetchService,
+		faviconService: faviconService,
+		authService:    authService,
 	}
 }
 
@@ -152,6 +154,59 @@ func (h *FeedHandler) Get(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, feed)
 }
 
+// Icon handles GET /api/v1/feeds/{id}/icon
+func (h *FeedHandler) Icon(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	feedID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid feed ID")
+		return
+	}
+
+	feed, err := h.feedService.GetFeed(feedID, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrFeedNotFound):
+			respondError(w, http.StatusNotFound, "Feed not found")
+		case errors.Is(err, service.ErrUnauthorized):
+			respondError(w, http.StatusForbidden, "Access denied")
+		default:
+			respondError(w, http.StatusInternalServerError, "Failed to get feed")
+		}
+		return
+	}
+
+	icon, err := h.faviconService.GetIcon(r.Context(), feed)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get icon")
+		return
+	}
+	if icon == nil {
+		respondError(w, http.StatusNotFound, "No icon available")
+		return
+	}
+
+	etag := `"` + icon.Hash + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Last-Modified", icon.FetchedAt.UTC().Format(http.TimeFormat))
+	w.Header().Set("Cache-Control", "private, max-age=86400")
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", icon.MimeType)
+	// SVG icons can carry script; never let them execute.
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; sandbox")
+	w.WriteHeader(http.StatusOK)
+	w.Write(icon.Data)
+}
+
 // Delete handles DELETE /api/v1/feeds/{id}
 func (h *FeedHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	userID, err := h.getUserFromRequest(r)
@@ -184,7 +239,8 @@ func (h *FeedHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 // UpdateRequest represents the request body for updating a feed.
 type UpdateRequest struct {
-	Title string `json:"title"`
+	Title    *string `json:"title"`
+	Category *string `json:"category"`
 }
 
 // Update handles PATCH /api/v1/feeds/{id}
@@ -207,12 +263,19 @@ func (h *FeedHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.Title == "" {
+	if req.Title == nil && req.Category == nil {
+		respondError(w, http.StatusBadRequest, "Nothing to update")
+		return
+	}
+	if req.Title != nil && *req.Title == "" {
 		respondError(w, http.StatusBadRequest, "Title is required")
 		return
 	}
 
-	feed, err := h.feedService.UpdateFeed(feedID, userID, req.Title)
+	feed, err := h.feedService.UpdateFeed(feedID, userID, service.UpdateFeedRequest{
+		Title:    req.Title,
+		Category: req.Category,
+	})
 	if err != nil {
 		switch {
 		case errors.Is(err, service.ErrFeedNotFound):
@@ -260,9 +323,10 @@ func (h *FeedHandler) ImportOPML(w http.ResponseWriter, r *http.Request) {
 	var opmlFeeds []service.OPMLFeedInfo
 	for _, f := range feeds {
 		opmlFeeds = append(opmlFeeds, service.OPMLFeedInfo{
-			URL:     f.URL,
-			Title:   f.Title,
-			SiteURL: f.SiteURL,
+			URL:      f.URL,
+			Title:    f.Title,
+			SiteURL:  f.SiteURL,
+			Category: f.Category,
 		})
 	}
 
@@ -294,9 +358,10 @@ func (h *FeedHandler) ExportOPML(w http.ResponseWriter, r *http.Request) {
 	var opmlFeeds []opml.FeedInfo
 	for _, f := range feeds {
 		opmlFeeds = append(opmlFeeds, opml.FeedInfo{
-			URL:     f.URL,
-			Title:   f.Title,
-			SiteURL: f.SiteURL,
+			URL:      f.URL,
+			Title:    f.Title,
+			SiteURL:  f.SiteURL,
+			Category: f.Category,
 		})
 	}
 
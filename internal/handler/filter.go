@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/michael/flowreader/internal/domain"
+)
+
+// parseArticleFilter reads the article list filters from the query string.
+// The caller sets the user scope.
+func parseArticleFilter(r *http.Request) (domain.ArticleFilter, error) {
+	q := r.URL.Query()
+	var filter domain.ArticleFilter
+
+	for _, value := range q["feed_id"] {
+		for _, raw := range strings.Split(value, ",") {
+			if raw = strings.TrimSpace(raw); raw == "" {
+				continue
+			}
+			id, err := uuid.Parse(raw)
+			if err != nil {
+				return filter, errors.New("Invalid feed_id")
+			}
+			filter.FeedIDs = append(filter.FeedIDs, id)
+		}
+	}
+
+	for _, value := range q["tag"] {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				filter.Tags = append(filter.Tags, name)
+			}
+		}
+	}
+
+	filter.Category = strings.TrimSpace(q.Get("category"))
+	filter.Author = strings.TrimSpace(q.Get("author"))
+	filter.Query = strings.TrimSpace(q.Get("q"))
+
+	var err error
+	if filter.IsRead, err = parseBoolParam(q.Get("read"), "read"); err != nil {
+		return filter, err
+	}
+	if q.Get("unread") == "true" {
+		isRead := false
+		filter.IsRead = &isRead
+	}
+	if filter.IsFavorite, err = parseBoolParam(q.Get("favorite"), "favorite"); err != nil {
+		return filter, err
+	}
+	if filter.HasImage, err = parseBoolParam(q.Get("has_image"), "has_image"); err != nil {
+		return filter, err
+	}
+	if filter.PublishedAfter, err = parseTimeParam(q.Get("since"), "since"); err != nil {
+		return filter, err
+	}
+	if filter.PublishedBefore, err = parseTimeParam(q.Get("until"), "until"); err != nil {
+		return filter, err
+	}
+
+	switch sort := domain.ArticleSort(q.Get("sort")); sort {
+	case "":
+		if filter.Query != "" {
+			filter.Sort = domain.SortRelevance
+		} else {
+			filter.Sort = domain.SortNewest
+		}
+	case domain.SortNewest, domain.SortOldest, domain.SortRelevance:
+		filter.Sort = sort
+	default:
+		return filter, errors.New("Invalid sort")
+	}
+
+	return filter, nil
+}
+
+// parseBoolParam parses an optional boolean query parameter.
+func parseBoolParam(value, name string) (*bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s", name)
+	}
+	return &b, nil
+}
+
+// parseTimeParam parses an optional RFC 3339 timestamp or YYYY-MM-DD date.
+func parseTimeParam(value, name string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t, err = time.Parse("2006-01-02", value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s", name)
+	}
+	return &t, nil
+}
+
+// parseMarkReadScope reads the scope of a mark-all-read request: the list
+// filters plus the before high-water mark, and the max_article_id one, which
+// the caller resolves to the article's ingest time.
+func parseMarkReadScope(r *http.Request) (domain.ArticleFilter, uuid.UUID, error) {
+	filter, err := parseArticleFilter(r)
+	if err != nil {
+		return filter, uuid.Nil, err
+	}
+
+	q := r.URL.Query()
+	if filter.CreatedBefore, err = parseTimeParam(q.Get("before"), "before"); err != nil {
+		return filter, uuid.Nil, err
+	}
+
+	var maxArticleID uuid.UUID
+	if raw := q.Get("max_article_id"); raw != "" {
+		if maxArticleID, err = uuid.Parse(raw); err != nil {
+			return filter, uuid.Nil, errors.New("Invalid max_article_id")
+		}
+	}
+
+	return filter, maxArticleID, nil
+}
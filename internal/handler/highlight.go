@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/michael/flowreader/internal/domain"
+	"github.com/michael/flowreader/internal/service"
+)
+
+// HighlightHandler handles highlight-related HTTP requests.
+type HighlightHandler struct {
+	highlightService *service.HighlightService
+	authService      *service.AuthService
+}
+
+// NewHighlightHandler creates a new highlight handler.
+func NewHighlightHandler(highlightService *service.HighlightService, authService *service.AuthService) *HighlightHandler {
+	return &HighlightHandler{
+		highlightService: highlightService,
+		authService:      authService,
+	}
+}
+
+// getUserFromRequest extracts the authenticated user from the request.
+func (h *HighlightHandler) getUserFromRequest(r *http.Request) (uuid.UUID, error) {
+	cookie, err := r.Cookie("session_id")
+	if err != nil {
+		return uuid.Nil, errors.New("not authenticated")
+	}
+
+	user, err := h.authService.GetUserByToken(cookie.Value)
+	if err != nil || user == nil {
+		return uuid.Nil, errors.New("invalid session")
+	}
+
+	return user.ID, nil
+}
+
+// respondHighlightError maps highlight service errors to HTTP responses.
+func respondHighlightError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrHighlightNotFound):
+		respondError(w, http.StatusNotFound, "Highlight not found")
+	case errors.Is(err, service.ErrArticleNotFound):
+		respondError(w, http.StatusNotFound, "Article not found")
+	case errors.Is(err, service.ErrUnauthorized):
+		respondError(w, http.StatusForbidden, "Access denied")
+	case errors.Is(err, service.ErrInvalidHighlight):
+		respondError(w, http.StatusBadRequest, "Invalid highlight")
+	default:
+		respondError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// List handles GET /api/v1/highlights
+func (h *HighlightHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	if offset < 0 {
+		offset = 0
+	}
+
+	highlights, err := h.highlightService.List(userID, limit, offset)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get highlights")
+		return
+	}
+	if highlights == nil {
+		highlights = []*domain.Highlight{}
+	}
+
+	respondJSON(w, http.StatusOK, highlights)
+}
+
+// ListForArticle handles GET /api/v1/articles/{id}/highlights
+func (h *HighlightHandler) ListForArticle(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	articleID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid article ID")
+		return
+	}
+
+	highlights, err := h.highlightService.ListForArticle(userID, articleID)
+	if err != nil {
+		respondHighlightError(w, err, "Failed to get highlights")
+		return
+	}
+	if highlights == nil {
+		highlights = []*domain.Highlight{}
+	}
+
+	respondJSON(w, http.StatusOK, highlights)
+}
+
+// Create handles POST /api/v1/articles/{id}/highlights
+func (h *HighlightHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	articleID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid article ID")
+		return
+	}
+
+	var req service.CreateHighlightRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	highlight, err := h.highlightService.Create(userID, articleID, req)
+	if err != nil {
+		respondHighlightError(w, err, "Failed to create highlight")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, highlight)
+}
+
+// Update handles PATCH /api/v1/highlights/{id}
+func (h *HighlightHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	highlightID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid highlight ID")
+		return
+	}
+
+	var req service.UpdateHighlightRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	highlight, err := h.highlightService.Update(highlightID, userID, req)
+	if err != nil {
+		respondHighlightError(w, err, "Failed to update highlight")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, highlight)
+}
+
+// Delete handles DELETE /api/v1/highlights/{id}
+func (h *HighlightHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	highlightID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid highlight ID")
+		return
+	}
+
+	if err := h.highlightService.Delete(highlightID, userID); err != nil {
+		respondHighlightError(w, err, "Failed to delete highlight")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Highlight deleted"})
+}
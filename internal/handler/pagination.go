@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/michael/flowreader/internal/domain"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// articlePage is the response envelope for cursor-paginated article lists.
+type articlePage struct {
+	Articles   []*domain.Article `json:"articles"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+	Total      *int              `json:"total,omitempty"`
+}
+
+// searchPage is the response envelope for cursor-paginated search results.
+type searchPage struct {
+	Results    []*domain.SearchResult `json:"results"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
+}
+
+// encodeCursor turns a cursor into an opaque URL-safe token.
+func encodeCursor(c *domain.ArticleCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor parses a token produced by encodeCursor.
+func decodeCursor(token string) (*domain.ArticleCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	var c domain.ArticleCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, errInvalidCursor
+	}
+	return &c, nil
+}
+
+// parsePage reads limit, offset and cursor from the query string. The second
+// return value reports whether the client asked for cursor pagination (the
+// cursor parameter is present, even empty for the first page).
+func parsePage(r *http.Request) (domain.Page, bool, error) {
+	q := r.URL.Query()
+
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	if offset < 0 {
+		offset = 0
+	}
+
+	page := domain.Page{Limit: limit, Offset: offset}
+
+	_, cursorMode := q["cursor"]
+	if token := q.Get("cursor"); token != "" {
+		after, err := decodeCursor(token)
+		if err != nil {
+			return page, true, err
+		}
+		page.After = after
+	}
+
+	return page, cursorMode, nil
+}
+
+// respondArticles writes an article list page. The next cursor and, when
+// known, the total are always exposed in the X-Next-Cursor and X-Total-Count
+// headers; clients using cursor pagination get the {articles, next_cursor,
+// total} envelope while offset clients keep the plain array.
+func respondArticles(w http.ResponseWriter, articles []*domain.Article, page domain.Page, cursorMode, ranked bool, total *int) {
+	if articles == nil {
+		articles = []*domain.Article{}
+	}
+
+	next := ""
+	if len(articles) == page.Limit {
+		next = encodeCursor(domain.CursorAfter(articles[len(articles)-1], ranked))
+		w.Header().Set("X-Next-Cursor", next)
+	}
+
+	if total != nil {
+		w.Header().Set("X-Total-Count", strconv.Itoa(*total))
+	}
+
+	if cursorMode {
+		respondJSON(w, http.StatusOK, articlePage{Articles: articles, NextCursor: next, Total: total})
+		return
+	}
+	respondJSON(w, http.StatusOK, articles)
+}
+
+// respondSearchResults writes a page of lightweight search results, following
+// the same conventions as respondArticles.
+func respondSearchResults(w http.ResponseWriter, results []*domain.SearchResult, page domain.Page, cursorMode bool) {
+	if results == nil {
+		results = []*domain.SearchResult{}
+	}
+
+	next := ""
+	if len(results) == page.Limit {
+		next = encodeCursor(results[len(results)-1].Cursor())
+		w.Header().Set("X-Next-Cursor", next)
+	}
+
+	if cursorMode {
+		respondJSON(w, http.StatusOK, searchPage{Results: results, NextCursor: next})
+		return
+	}
+	respondJSON(w, http.StatusOK, results)
+}
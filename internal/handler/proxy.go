@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/michael/flowreader/internal/service"
+	"github.com/michael/flowreader/internal/utils"
+)
+
+// ProxyHandler relays remote resources through the server.
+type ProxyHandler struct {
+	imageProxy *service.ImageProxyService
+}
+
+// NewProxyHandler creates a new proxy handler.
+func NewProxyHandler(imageProxy *service.ImageProxyService) *ProxyHandler {
+	return &ProxyHandler{imageProxy: imageProxy}
+}
+
+// Image handles GET /api/v1/proxy/image?url=...&sig=...
+//
+// The endpoint is not session-protected: <img> loads must work everywhere the
+// article is rendered. The HMAC signature keeps it from being an open proxy.
+func (h *ProxyHandler) Image(w http.ResponseWriter, r *http.Request) {
+	rawURL := r.URL.Query().Get("url")
+	sig := r.URL.Query().Get("sig")
+	if rawURL == "" || sig == "" {
+		respondError(w, http.StatusBadRequest, "Missing url or signature")
+		return
+	}
+
+	if !h.imageProxy.Verify(rawURL, sig) {
+		respondError(w, http.StatusForbidden, "Invalid signature")
+		return
+	}
+
+	// Signed URLs are immutable, so the signature doubles as a strong ETag.
+	etag := `"` + sig + `"`
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	img, err := h.imageProxy.Fetch(r.Context(), rawURL)
+	if err != nil {
+		var blocked *utils.ErrBlockedHost
+		switch {
+		case errors.As(err, &blocked):
+			respondError(w, http.StatusForbidden, "Blocked host")
+		case errors.Is(err, service.ErrImageTooLarge):
+			respondError(w, http.StatusRequestEntityTooLarge, "Image too large")
+		case errors.Is(err, service.ErrUnsupportedImageType):
+			respondError(w, http.StatusUnsupportedMediaType, "Unsupported image type")
+		default:
+			respondError(w, http.StatusBadGateway, "Failed to fetch image")
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", img.ContentType)
+	w.Header().Set("Cache-Control", "public, max-age=2592000, immutable")
+	w.Header().Set("ETag", etag)
+	// SVG can carry script; never let a proxied image execute anything.
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; sandbox")
+	w.WriteHeader(http.StatusOK)
+	w.Write(img.Data)
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/michael/flowreader/internal/service"
+)
+
+// QuickFindHandler serves the quick switcher (command palette).
+type QuickFindHandler struct {
+	quickFindService *service.QuickFindService
+	authService      *service.AuthService
+}
+
+// NewQuickFindHandler creates a new quick switcher handler.
+func NewQuickFindHandler(quickFindService *service.QuickFindService, authService *service.AuthService) *QuickFindHandler {
+	return &QuickFindHandler{
+		quickFindService: quickFindService,
+		authService:      authService,
+	}
+}
+
+// getUserFromRequest extracts the authenticated user from the request.
+func (h *QuickFindHandler) getUserFromRequest(r *http.Request) (uuid.UUID, error) {
+	cookie, err := r.Cookie("session_id")
+	if err != nil {
+		return uuid.Nil, errors.New("not authenticated")
+	}
+
+	user, err := h.authService.GetUserByToken(cookie.Value)
+	if err != nil || user == nil {
+		return uuid.Nil, errors.New("invalid session")
+	}
+
+	return user.ID, nil
+}
+
+// Find handles GET /api/v1/quickfind?q=&limit=
+//
+// It returns the feeds, categories and article titles matching q, each
+// group best match first. Matching is fuzzy, so prefixes and typos match.
+func (h *QuickFindHandler) Find(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	limit := service.DefaultQuickFindLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			respondError(w, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+		limit = n
+	}
+
+	results, err := h.quickFindService.Find(userID, r.URL.Query().Get("q"), limit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to search")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, results)
+}
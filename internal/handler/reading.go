@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/michael/flowreader/internal/service"
+)
+
+// ReadingHandler handles reading history and statistics HTTP requests.
+type ReadingHandler struct {
+	readingService *service.ReadingService
+	authService    *service.AuthService
+}
+
+// NewReadingHandler creates a new reading handler.
+func NewReadingHandler(readingService *service.ReadingService, authService *service.AuthService) *ReadingHandler {
+	return &ReadingHandler{
+		readingService: readingService,
+		authService:    authService,
+	}
+}
+
+// OpenRequest represents the optional body of POST /articles/{id}/open.
+type OpenRequest struct {
+	DwellSeconds *int `json:"dwell_seconds"`
+}
+
+// getUserFromRequest extracts the authenticated user from the request.
+func (h *ReadingHandler) getUserFromRequest(r *http.Request) (uuid.UUID, error) {
+	cookie, err := r.Cookie("session_id")
+	if err != nil {
+		return uuid.Nil, errors.New("not authenticated")
+	}
+
+	user, err := h.authService.GetUserByToken(cookie.Value)
+	if err != nil || user == nil {
+		return uuid.Nil, errors.New("invalid session")
+	}
+
+	return user.ID, nil
+}
+
+// RecordOpen handles POST /api/v1/articles/{id}/open
+//
+// Clients report that an article was opened, with the time spent reading it
+// in dwell_seconds when known.
+func (h *ReadingHandler) RecordOpen(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	articleID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid article ID")
+		return
+	}
+
+	// The body is optional
+	var req OpenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	event, err := h.readingService.RecordOpen(userID, articleID, req.DwellSeconds)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrArticleNotFound):
+			respondError(w, http.StatusNotFound, "Article not found")
+		case errors.Is(err, service.ErrUnauthorized):
+			respondError(w, http.StatusForbidden, "Access denied")
+		case errors.Is(err, service.ErrInvalidReadingEvent):
+			respondError(w, http.StatusBadRequest, "Invalid dwell time")
+		default:
+			respondError(w, http.StatusInternalServerError, "Failed to record reading event")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, event)
+}
+
+// Stats handles GET /api/v1/stats/reading
+//
+// Query parameters: days (default 30) and tz, the IANA time zone used for
+// day boundaries (default UTC).
+func (h *ReadingHandler) Stats(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	days := service.DefaultStatsDays
+	if v := r.URL.Query().Get("days"); v != "" {
+		days, err = strconv.Atoi(v)
+		if err != nil || days <= 0 || days > service.MaxStatsDays {
+			respondError(w, http.StatusBadRequest, "Invalid days parameter")
+			return
+		}
+	}
+
+	stats, err := h.readingService.Stats(userID, days, r.URL.Query().Get("tz"))
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidTimezone) {
+			respondError(w, http.StatusBadRequest, "Invalid timezone")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to compute reading statistics")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, stats)
+}
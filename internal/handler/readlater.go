@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/michael/flowreader/internal/domain"
+)
+
+// ReadLaterRequest represents the optional body of PUT /articles/{id}/read-later.
+type ReadLaterRequest struct {
+	Top         bool       `json:"top"`
+	SnoozeUntil *time.Time `json:"snooze_until"`
+}
+
+// ReorderReadLaterRequest represents the request body for reordering the queue.
+type ReorderReadLaterRequest struct {
+	IDs []uuid.UUID `json:"ids"`
+}
+
+// ListReadLater handles GET /api/v1/read-later
+//
+// Snoozed items are hidden unless snoozed=true.
+func (h *ArticleHandler) ListReadLater(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getCurrentUser(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	if offset < 0 {
+		offset = 0
+	}
+
+	includeSnoozed := r.URL.Query().Get("snoozed") == "true"
+
+	items, err := h.readLaterRepo.List(user.ID, includeSnoozed, limit, offset)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get read later queue")
+		return
+	}
+	if items == nil {
+		items = []*domain.ReadLaterItem{}
+	}
+
+	if len(items) > 0 {
+		ids := make([]uuid.UUID, len(items))
+		for i, item := range items {
+			ids[i] = item.ArticleID
+		}
+		articles, err := h.articleRepo.Query(domain.ArticleFilter{UserID: user.ID, IDs: ids}, domain.Page{Limit: len(ids)})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to get read later queue")
+			return
+		}
+		h.prepareArticles(user, articles)
+
+		byID := make(map[uuid.UUID]*domain.Article, len(articles))
+		for _, a := range articles {
+			byID[a.ID] = a
+		}
+		for _, item := range items {
+			item.Article = byID[item.ArticleID]
+		}
+	}
+
+	respondJSON(w, http.StatusOK, items)
+}
+
+// AddReadLater handles PUT /api/v1/articles/{id}/read-later
+func (h *ArticleHandler) AddReadLater(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	articleID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid article ID")
+		return
+	}
+
+	// The body is optional
+	var req ReadLaterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.SnoozeUntil != nil && !req.SnoozeUntil.After(time.Now()) {
+		respondError(w, http.StatusBadRequest, "snooze_until must be in the future")
+		return
+	}
+
+	item, err := h.readLaterRepo.Add(userID, articleID, req.Top, req.SnoozeUntil)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to add to read later")
+		return
+	}
+	if item == nil {
+		respondError(w, http.StatusNotFound, "Article not found")
+		return
+	}
+
+	if h.hub != nil {
+		payload := map[string]interface{}{
+			"article_id":    articleID,
+			"read_later":    true,
+			"position":      item.Position,
+			"snoozed_until": item.SnoozedUntil,
+		}
+		if item.SnoozedUntil != nil {
+			payload["is_read"] = true
+		}
+		h.hub.BroadcastToUser(userID, "read_later_updated", payload)
+	}
+
+	respondJSON(w, http.StatusOK, item)
+}
+
+// RemoveReadLater handles DELETE /api/v1/articles/{id}/read-later
+func (h *ArticleHandler) RemoveReadLater(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	articleID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid article ID")
+		return
+	}
+
+	removed, err := h.readLaterRepo.Remove(userID, articleID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to remove from read later")
+		return
+	}
+
+	if removed && h.hub != nil {
+		h.hub.BroadcastToUser(userID, "read_later_updated", map[string]interface{}{
+			"article_id": articleID,
+			"read_later": false,
+		})
+	}
+
+	respondJSON(w, http.StatusOK, map[string]bool{"read_later": false})
+}
+
+// ReorderReadLater handles POST /api/v1/read-later/reorder
+//
+// The listed articles move to the front of the queue in the given order.
+func (h *ArticleHandler) ReorderReadLater(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	var req ReorderReadLaterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if len(req.IDs) == 0 || len(req.IDs) > maxBatchArticles {
+		respondError(w, http.StatusBadRequest, "Invalid article list")
+		return
+	}
+
+	if err := h.readLaterRepo.Reorder(userID, req.IDs); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to reorder read later queue")
+		return
+	}
+
+	if h.hub != nil {
+		h.hub.BroadcastToUser(userID, "read_later_reordered", map[string]interface{}{
+			"ids": req.IDs,
+		})
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Read later queue reordered"})
+}
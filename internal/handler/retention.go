@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/michael/flowreader/internal/service"
+)
+
+// RetentionHandler handles retention policy HTTP requests.
+type RetentionHandler struct {
+	retentionService *service.RetentionService
+	authService      *service.AuthService
+}
+
+// NewRetentionHandler creates a new retention handler.
+func NewRetentionHandler(retentionService *service.RetentionService, authService *service.AuthService) *RetentionHandler {
+	return &RetentionHandler{
+		retentionService: retentionService,
+		authService:      authService,
+	}
+}
+
+// getUserFromRequest extracts the authenticated user from the request.
+func (h *RetentionHandler) getUserFromRequest(r *http.Request) (uuid.UUID, error) {
+	cookie, err := r.Cookie("session_id")
+	if err != nil {
+		return uuid.Nil, errors.New("not authenticated")
+	}
+
+	user, err := h.authService.GetUserByToken(cookie.Value)
+	if err != nil || user == nil {
+		return uuid.Nil, errors.New("invalid session")
+	}
+
+	return user.ID, nil
+}
+
+// respondRetentionError maps retention service errors to HTTP responses.
+func respondRetentionError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrFeedNotFound):
+		respondError(w, http.StatusNotFound, "Feed not found")
+	case errors.Is(err, service.ErrUnauthorized):
+		respondError(w, http.StatusForbidden, "Access denied")
+	case errors.Is(err, service.ErrInvalidRetentionPolicy):
+		respondError(w, http.StatusBadRequest, "Invalid retention policy")
+	default:
+		respondError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// decodeRetentionRequest decodes a retention policy request body.
+func decodeRetentionRequest(w http.ResponseWriter, r *http.Request) (service.RetentionRequest, bool) {
+	var req service.RetentionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return req, false
+	}
+	return req, true
+}
+
+// Get handles GET /api/v1/retention
+//
+// It returns the instance policy and the user's own policies; the user and
+// feed policies are absent when inherited.
+func (h *RetentionHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	settings, err := h.retentionService.GetSettings(userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get retention policies")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, settings)
+}
+
+// SetUser handles PUT /api/v1/retention
+func (h *RetentionHandler) SetUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	req, ok := decodeRetentionRequest(w, r)
+	if !ok {
+		return
+	}
+
+	policy, err := h.retentionService.SetUserPolicy(userID, req)
+	if err != nil {
+		respondRetentionError(w, err, "Failed to save retention policy")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, policy)
+}
+
+// DeleteUser handles DELETE /api/v1/retention
+func (h *RetentionHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	if err := h.retentionService.DeleteUserPolicy(userID); err != nil {
+		respondRetentionError(w, err, "Failed to delete retention policy")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Retention policy deleted"})
+}
+
+// DryRun handles GET /api/v1/retention/dry-run
+//
+// It reports how many of the user's articles each policy in effect would
+// delete on the next cleanup, without deleting anything.
+func (h *RetentionHandler) DryRun(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	counts, err := h.retentionService.DryRun(userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to evaluate retention policies")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, counts)
+}
+
+// GetFeed handles GET /api/v1/feeds/{id}/retention
+//
+// It returns null when the feed inherits its policy.
+func (h *RetentionHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	feedID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid feed ID")
+		return
+	}
+
+	policy, err := h.retentionService.GetFeedPolicy(feedID, userID)
+	if err != nil {
+		respondRetentionError(w, err, "Failed to get retention policy")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, policy)
+}
+
+// SetFeed handles PUT /api/v1/feeds/{id}/retention
+func (h *RetentionHandler) SetFeed(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	feedID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid feed ID")
+		return
+	}
+
+	req, ok := decodeRetentionRequest(w, r)
+	if !ok {
+		return
+	}
+
+	policy, err := h.retentionService.SetFeedPolicy(feedID, userID, req)
+	if err != nil {
+		respondRetentionError(w, err, "Failed to save retention policy")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, policy)
+}
+
+// DeleteFeed handles DELETE /api/v1/feeds/{id}/retention
+func (h *RetentionHandler) DeleteFeed(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	feedID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid feed ID")
+		return
+	}
+
+	if err := h.retentionService.DeleteFeedPolicy(feedID, userID); err != nil {
+		respondRetentionError(w, err, "Failed to delete retention policy")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Retention policy deleted"})
+}
+
+// GetInstance handles GET /api/v1/admin/retention
+func (h *RetentionHandler) GetInstance(w http.ResponseWriter, r *http.Request) {
+	policy, err := h.retentionService.GetInstancePolicy()
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get retention policy")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, policy)
+}
+
+// SetInstance handles PUT /api/v1/admin/retention
+func (h *RetentionHandler) SetInstance(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeRetentionRequest(w, r)
+	if !ok {
+		return
+	}
+
+	policy, err := h.retentionService.SetInstancePolicy(req)
+	if err != nil {
+		respondRetentionError(w, err, "Failed to save retention policy")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, policy)
+}
+
+// DryRunInstance handles GET /api/v1/admin/retention/dry-run
+//
+// It reports what the next cleanup would delete across all users.
+func (h *RetentionHandler) DryRunInstance(w http.ResponseWriter, r *http.Request) {
+	counts, err := h.retentionService.DryRun(uuid.Nil)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to evaluate retention policies")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, counts)
+}
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/michael/flowreader/internal/domain"
+	"github.com/michael/flowreader/internal/search"
+	"github.com/michael/flowreader/internal/service"
+)
+
+// SavedSearchHandler handles saved search (smart folder) HTTP requests.
+type SavedSearchHandler struct {
+	savedSearchService *service.SavedSearchService
+	authService        *service.AuthService
+}
+
+// NewSavedSearchHandler creates a new saved search handler.
+func NewSavedSearchHandler(savedSearchService *service.SavedSearchService, authService *service.AuthService) *SavedSearchHandler {
+	return &SavedSearchHandler{
+		savedSearchService: savedSearchService,
+		authService:        authService,
+	}
+}
+
+// getUserFromRequest extracts the authenticated user from the request.
+func (h *SavedSearchHandler) getUserFromRequest(r *http.Request) (uuid.UUID, error) {
+	cookie, err := r.Cookie("session_id")
+	if err != nil {
+		return uuid.Nil, errors.New("not authenticated")
+	}
+
+	user, err := h.authService.GetUserByToken(cookie.Value)
+	if err != nil || user == nil {
+		return uuid.Nil, errors.New("invalid session")
+	}
+
+	return user.ID, nil
+}
+
+// respondSavedSearchError maps saved search service errors to HTTP responses.
+func respondSavedSearchError(w http.ResponseWriter, err error, fallback string) {
+	var perr *search.Error
+	switch {
+	case errors.As(err, &perr):
+		respondJSON(w, http.StatusBadRequest, map[string]interface{}{
+			"error":   perr.Message,
+			"details": perr,
+		})
+	case errors.Is(err, service.ErrSavedSearchNotFound):
+		respondError(w, http.StatusNotFound, "Saved search not found")
+	case errors.Is(err, service.ErrUnauthorized):
+		respondError(w, http.StatusForbidden, "Access denied")
+	case errors.Is(err, service.ErrSavedSearchExists):
+		respondError(w, http.StatusConflict, "Saved search already exists")
+	case errors.Is(err, service.ErrInvalidSavedSearch):
+		respondError(w, http.StatusBadRequest, "Invalid saved search")
+	default:
+		respondError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// List handles GET /api/v1/saved-searches
+func (h *SavedSearchHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	searches, err := h.savedSearchService.ListSavedSearches(userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get saved searches")
+		return
+	}
+	if searches == nil {
+		searches = []*domain.SavedSearch{}
+	}
+
+	respondJSON(w, http.StatusOK, searches)
+}
+
+// Create handles POST /api/v1/saved-searches
+func (h *SavedSearchHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	var req service.SavedSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	search, err := h.savedSearchService.CreateSavedSearch(userID, req)
+	if err != nil {
+		respondSavedSearchError(w, err, "Failed to create saved search")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, search)
+}
+
+// Update handles PATCH /api/v1/saved-searches/{id}
+func (h *SavedSearchHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	searchID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid saved search ID")
+		return
+	}
+
+	var req service.SavedSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	search, err := h.savedSearchService.UpdateSavedSearch(searchID, userID, req)
+	if err != nil {
+		respondSavedSearchError(w, err, "Failed to update saved search")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, search)
+}
+
+// Delete handles DELETE /api/v1/saved-searches/{id}
+func (h *SavedSearchHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	searchID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid saved search ID")
+		return
+	}
+
+	if err := h.savedSearchService.DeleteSavedSearch(searchID, userID); err != nil {
+		respondSavedSearchError(w, err, "Failed to delete saved search")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Saved search deleted"})
+}
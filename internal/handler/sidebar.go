@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/michael/flowreader/internal/domain"
+	"github.com/michael/flowreader/internal/service"
+)
+
+// SidebarHandler serves the navigation data of the reader: feeds, tags and
+// saved searches with their unread counts.
+type SidebarHandler struct {
+	feedService        *service.FeedService
+	tagService         *service.TagService
+	savedSearchService *service.SavedSearchService
+	authService        *service.AuthService
+}
+
+// NewSidebarHandler creates a new sidebar handler.
+func NewSidebarHandler(feedService *service.FeedService, tagService *service.TagService, savedSearchService *service.SavedSearchService, authService *service.AuthService) *SidebarHandler {
+	return &SidebarHandler{
+		feedService:        feedService,
+		tagService:         tagService,
+		savedSearchService: savedSearchService,
+		authService:        authService,
+	}
+}
+
+// SidebarResponse is the sidebar payload.
+type SidebarResponse struct {
+	TotalUnread   int                   `json:"total_unread"`
+	Feeds         []*domain.Feed        `json:"feeds"`
+	Tags          []*domain.Tag         `json:"tags"`
+	SavedSearches []*domain.SavedSearch `json:"saved_searches"`
+}
+
+// getUserFromRequest extracts the authenticated user from the request.
+func (h *SidebarHandler) getUserFromRequest(r *http.Request) (uuid.UUID, error) {
+	cookie, err := r.Cookie("session_id")
+	if err != nil {
+		return uuid.Nil, errors.New("not authenticated")
+	}
+
+	user, err := h.authService.GetUserByToken(cookie.Value)
+	if err != nil || user == nil {
+		return uuid.Nil, errors.New("invalid session")
+	}
+
+	return user.ID, nil
+}
+
+// Get handles GET /api/v1/sidebar
+func (h *SidebarHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	resp := SidebarResponse{
+		Feeds:         []*domain.Feed{},
+		Tags:          []*domain.Tag{},
+		SavedSearches: []*domain.SavedSearch{},
+	}
+
+	feeds, err := h.feedService.GetUserFeeds(userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get feeds")
+		return
+	}
+	if feeds != nil {
+		resp.Feeds = feeds
+	}
+	for _, feed := range feeds {
+		resp.TotalUnread += feed.UnreadCount
+	}
+
+	tags, err := h.tagService.ListTags(userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get tags")
+		return
+	}
+	if tags != nil {
+		resp.Tags = tags
+	}
+
+	searches, err := h.savedSearchService.ListSavedSearches(userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get saved searches")
+		return
+	}
+	if searches != nil {
+		resp.SavedSearches = searches
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/michael/flowreader/internal/domain"
+	"github.com/michael/flowreader/internal/service"
+)
+
+// TagHandler handles tag-related HTTP requests.
+type TagHandler struct {
+	tagService  *service.TagService
+	authService *service.AuthService
+}
+
+// NewTagHandler creates a new tag handler.
+func NewTagHandler(tagService *service.TagService, authService *service.AuthService) *TagHandler {
+	return &TagHandler{
+		tagService:  tagService,
+		authService: authService,
+	}
+}
+
+// getUserFromRequest extracts the authenticated user from the request.
+func (h *TagHandler) getUserFromRequest(r *http.Request) (uuid.UUID, error) {
+	cookie, err := r.Cookie("session_id")
+	if err != nil {
+		return uuid.Nil, errors.New("not authenticated")
+	}
+
+	user, err := h.authService.GetUserByToken(cookie.Value)
+	if err != nil || user == nil {
+		return uuid.Nil, errors.New("invalid session")
+	}
+
+	return user.ID, nil
+}
+
+// respondTagError maps tag service errors to HTTP responses.
+func respondTagError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrTagNotFound):
+		respondError(w, http.StatusNotFound, "Tag not found")
+	case errors.Is(err, service.ErrUnauthorized):
+		respondError(w, http.StatusForbidden, "Access denied")
+	case errors.Is(err, service.ErrTagExists):
+		respondError(w, http.StatusConflict, "Tag already exists")
+	case errors.Is(err, service.ErrInvalidTagName):
+		respondError(w, http.StatusBadRequest, "Invalid tag name")
+	case errors.Is(err, service.ErrInvalidColor):
+		respondError(w, http.StatusBadRequest, "Invalid color (expected #rrggbb)")
+	default:
+		respondError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// List handles GET /api/v1/tags
+func (h *TagHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	tags, err := h.tagService.ListTags(userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get tags")
+		return
+	}
+	if tags == nil {
+		tags = []*domain.Tag{}
+	}
+
+	respondJSON(w, http.StatusOK, tags)
+}
+
+// Create handles POST /api/v1/tags
+func (h *TagHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	var req service.TagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	tag, err := h.tagService.CreateTag(userID, req)
+	if err != nil {
+		respondTagError(w, err, "Failed to create tag")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, tag)
+}
+
+// Update handles PATCH /api/v1/tags/{id}
+func (h *TagHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	tagID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid tag ID")
+		return
+	}
+
+	var req service.TagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	tag, err := h.tagService.UpdateTag(tagID, userID, req)
+	if err != nil {
+		respondTagError(w, err, "Failed to update tag")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, tag)
+}
+
+// Delete handles DELETE /api/v1/tags/{id}
+func (h *TagHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	tagID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid tag ID")
+		return
+	}
+
+	if err := h.tagService.DeleteTag(tagID, userID); err != nil {
+		respondTagError(w, err, "Failed to delete tag")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Tag deleted"})
+}
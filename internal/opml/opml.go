@@ -111,14 +111,33 @@ func Generate(title string, feeds []FeedInfo) ([]byte, error) {
 		},
 	}
 
+	// Feeds with a category are nested in a folder outline per category,
+	// in order of first appearance.
+	folders := make(map[string]int)
 	for _, feed := range feeds {
-		opml.Body.Outlines = append(opml.Body.Outlines, Outline{
+		outline := Outline{
 			Text:    feed.Title,
 			Title:   feed.Title,
 			Type:    "rss",
 			XMLURL:  feed.URL,
 			HTMLURL: feed.SiteURL,
-		})
+		}
+
+		if feed.Category == "" {
+			opml.Body.Outlines = append(opml.Body.Outlines, outline)
+			continue
+		}
+
+		idx, ok := folders[feed.Category]
+		if !ok {
+			idx = len(opml.Body.Outlines)
+			folders[feed.Category] = idx
+			opml.Body.Outlines = append(opml.Body.Outlines, Outline{
+				Text:  feed.Category,
+				Title: feed.Category,
+			})
+		}
+		opml.Body.Outlines[idx].Outlines = append(opml.Body.Outlines[idx].Outlines, outline)
 	}
 
 	output, err := xml.MarshalIndent(opml, "", "  ")
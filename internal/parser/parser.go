@@ -37,6 +37,7 @@ type ParsedFeed struct {
 	Description string
 	SiteURL     string
 	ImageURL    string
+	Language    string // declared language (<language>, xml:lang, dc:language)
 	Articles    []*domain.Article
 }
 
@@ -75,6 +76,7 @@ func (p *FeedParser) Parse(ctx context.Context, feedURL string, feedID uuid.UUID
 	parsed := &ParsedFeed{
 		Title:       feed.Title,
 		Description: feed.Description,
+		Language:    feed.Language,
 	}
 
 	if feed.Link != "" {
@@ -98,6 +100,11 @@ func (p *FeedParser) Parse(ctx context.Context, feedURL string, feedID uuid.UUID
 			article.URL = item.Link
 		}
 
+		// FeedBurner wraps links in feedproxy redirects but keeps the original
+		if origLink := feedburnerOrigLink(item); origLink != "" {
+			article.URL = origLink
+		}
+
 		if item.Content != "" {
 			article.Content = item.Content
 		}
@@ -124,6 +131,11 @@ func (p *FeedParser) Parse(ctx context.Context, feedURL string, feedID uuid.UUID
 			article.PublishedAt = item.UpdatedParsed
 		}
 
+		// Item-level declaration; detection happens at ingest
+		if item.DublinCoreExt != nil && len(item.DublinCoreExt.Language) > 0 {
+			article.Language = item.DublinCoreExt.Language[0]
+		}
+
 		article.CreatedAt = time.Now()
 
 		parsed.Articles = append(parsed.Articles, article)
@@ -143,6 +155,17 @@ func getGUID(item *gofeed.Item) string {
 	return item.Title // Last resort fallback
 }
 
+// feedburnerOrigLink returns the unwrapped link FeedBurner stores in its
+// feedburner:origLink extension, if any.
+func feedburnerOrigLink(item *gofeed.Item) string {
+	if fb, ok := item.Extensions["feedburner"]; ok {
+		if links, ok := fb["origLink"]; ok && len(links) > 0 {
+			return strings.TrimSpace(links[0].Value)
+		}
+	}
+	return ""
+}
+
 // findImage attempts to find the best image for a feed item.
 func findImage(item *gofeed.Item) string {
 	// 1. Check Enclosures
@@ -0,0 +1,524 @@
+// Package readability extracts the main article from a web page.
+//
+// It is a compact Go take on the scoring approach popularised by Arc90's
+// Readability: paragraph-like nodes award points to their ancestors based on
+// text length and comma count, class/id names nudge scores up or down, and
+// link-heavy nodes are penalised. The best-scoring node, plus siblings that
+// look like part of the same article, becomes the extracted content.
+package readability
+
+import (
+	"errors"
+	"math"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// ErrNoContent is returned when no plausible article body could be found.
+var ErrNoContent = errors.New("no readable content found")
+
+// Article is the structured result of an extraction.
+type Article struct {
+	Title     string
+	Byline    string
+	SiteName  string
+	LeadImage string
+	// Content is the cleaned article body as an HTML fragment. It is NOT
+	// sanitized against XSS; render it through a sanitizer.
+	Content string
+	// Text is the plain-text rendition of Content, one block per line.
+	Text string
+}
+
+var (
+	unlikelyCandidates = regexp.MustCompile(`(?i)-ad-|ai2html|banner|breadcrumbs|combx|comment|community|cover-wrap|disqus|extra|footer|gdpr|header|legends|menu|related|remark|replies|rss|shoutbox|sidebar|skyscraper|social|sponsor|supplemental|ad-break|agegate|pagination|pager|popup|yom-remote|newsletter|subscribe|cookie`)
+	maybeCandidate     = regexp.MustCompile(`(?i)and|article|body|column|content|main|shadow`)
+	positiveNames      = regexp.MustCompile(`(?i)article|body|content|entry|hentry|h-entry|main|page|pagination|post|text|blog|story`)
+	negativeNames      = regexp.MustCompile(`(?i)-ad-|hidden|^hid$| hid$| hid |^hid |banner|combx|comment|com-|contact|foot|footer|footnote|gdpr|masthead|media|meta|outbrain|promo|related|scroll|share|shoutbox|sidebar|skyscraper|sponsor|shopping|tags|tool|widget|newsletter|subscribe`)
+	titleSeparators    = regexp.MustCompile(`\s[\|\-–—\\/>»]\s`)
+	multiSpace         = regexp.MustCompile(`[ \t\f\v\r]+`)
+)
+
+// blockTags are elements that, when present inside a <div>, mean the div is
+// a container rather than a paragraph in disguise.
+var blockTags = map[string]bool{
+	"blockquote": true, "dl": true, "div": true, "ol": true, "p": true,
+	"pre": true, "table": true, "ul": true, "section": true, "article": true,
+	"figure": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true,
+	"h6": true, "header": true, "footer": true,
+}
+
+const (
+	minParagraphLength = 25
+	siblingScoreRatio  = 0.2
+)
+
+// Parse extracts the article from a parsed document. pageURL, when non-nil,
+// is used to resolve relative links and image sources.
+func Parse(doc *goquery.Document, pageURL *url.URL) (*Article, error) {
+	article := &Article{
+		Title:     extractTitle(doc),
+		Byline:    metaContent(doc, `meta[name="author"], meta[property="article:author"], meta[name="byl"]`),
+		SiteName:  metaContent(doc, `meta[property="og:site_name"]`),
+		LeadImage: metaContent(doc, `meta[property="og:image"], meta[name="twitter:image"], meta[property="twitter:image"]`),
+	}
+
+	prepare(doc)
+
+	if article.Byline == "" {
+		article.Byline = findByline(doc)
+	}
+
+	body := doc.Find("body")
+	if body.Length() == 0 {
+		return nil, ErrNoContent
+	}
+
+	content := grabArticle(body)
+	if content == nil {
+		return nil, ErrNoContent
+	}
+
+	clean(content, article.Title)
+	absolutize(content, pageURL)
+
+	if article.LeadImage == "" {
+		article.LeadImage, _ = content.Find("img[src]").First().Attr("src")
+	} else if pageURL != nil {
+		if ref, err := pageURL.Parse(article.LeadImage); err == nil {
+			article.LeadImage = ref.String()
+		}
+	}
+
+	htmlContent, err := content.Html()
+	if err != nil {
+		return nil, err
+	}
+	article.Content = strings.TrimSpace(htmlContent)
+	article.Text = ToText(content)
+
+	if article.Text == "" {
+		return nil, ErrNoContent
+	}
+
+	return article, nil
+}
+
+// ToText renders a selection as plain text with one block per line.
+func ToText(sel *goquery.Selection) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			b.WriteString(multiSpace.ReplaceAllString(n.Data, " "))
+			return
+		case html.ElementNode:
+			if n.Data == "br" {
+				b.WriteByte('\n')
+				return
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+		if n.Type == html.ElementNode && isBlock(n.Data) {
+			b.WriteByte('\n')
+		}
+	}
+	for _, n := range sel.Nodes {
+		walk(n)
+	}
+
+	var lines []string
+	for _, line := range strings.Split(b.String(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isBlock(tag string) bool {
+	switch tag {
+	case "p", "div", "li", "h1", "h2", "h3", "h4", "h5", "h6", "blockquote",
+		"pre", "tr", "section", "article", "figure", "figcaption", "dt", "dd", "table", "ul", "ol":
+		return true
+	}
+	return false
+}
+
+// extractTitle prefers social metadata, then <title> with the site name
+// stripped, then the first <h1>.
+func extractTitle(doc *goquery.Document) string {
+	if t := metaContent(doc, `meta[property="og:title"], meta[name="twitter:title"]`); t != "" {
+		return t
+	}
+
+	title := strings.TrimSpace(doc.Find("title").First().Text())
+	if loc := titleSeparators.FindAllStringIndex(title, -1); len(loc) > 0 {
+		// Keep the longest part: "Article | Site" or "Site — Article".
+		first := strings.TrimSpace(title[:loc[0][0]])
+		last := strings.TrimSpace(title[loc[len(loc)-1][1]:])
+		if len(strings.Fields(first)) >= len(strings.Fields(last)) {
+			title = first
+		} else {
+			title = last
+		}
+	}
+	if title == "" {
+		title = strings.TrimSpace(doc.Find("h1").First().Text())
+	}
+	return title
+}
+
+func metaContent(doc *goquery.Document, selector string) string {
+	var value string
+	doc.Find(selector).EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		value = strings.TrimSpace(s.AttrOr("content", ""))
+		return value == ""
+	})
+	return value
+}
+
+// findByline looks for an author element in the page body.
+func findByline(doc *goquery.Document) string {
+	var byline string
+	doc.Find(`[rel="author"], [itemprop~="author"], .byline, .author, .p-author`).EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		text := strings.Join(strings.Fields(s.Text()), " ")
+		if text != "" && len(text) < 100 {
+			byline = text
+			return false
+		}
+		return true
+	})
+	return byline
+}
+
+// prepare strips elements that never belong to an article and unlikely
+// candidates based on their class and id.
+func prepare(doc *goquery.Document) {
+	doc.Find("script, style, noscript, template, link, meta, form, button, input, select, textarea, nav, aside, footer, object, embed, svg").Remove()
+
+	doc.Find("body *").Each(func(_ int, s *goquery.Selection) {
+		tag := goquery.NodeName(s)
+		if tag == "body" || tag == "a" || tag == "article" || tag == "main" {
+			return
+		}
+		names := s.AttrOr("class", "") + " " + s.AttrOr("id", "")
+		if strings.TrimSpace(names) == "" {
+			return
+		}
+		if unlikelyCandidates.MatchString(names) && !maybeCandidate.MatchString(names) && s.Closest("article, main").Length() == 0 {
+			s.Remove()
+			return
+		}
+		if role := s.AttrOr("role", ""); role == "complementary" || role == "navigation" || role == "dialog" {
+			s.Remove()
+		}
+	})
+}
+
+// scorer accumulates content scores per DOM node.
+type scorer struct {
+	scores map[*html.Node]float64
+}
+
+func (sc *scorer) initialize(n *html.Node) {
+	if _, ok := sc.scores[n]; ok {
+		return
+	}
+	score := 0.0
+	switch n.Data {
+	case "div", "article", "main", "section":
+		score += 5
+	case "pre", "td", "blockquote":
+		score += 3
+	case "address", "ol", "ul", "dl", "dd", "dt", "li", "form":
+		score -= 3
+	case "h1", "h2", "h3", "h4", "h5", "h6", "th":
+		score -= 5
+	}
+	sc.scores[n] = score + classWeight(goquery.NewDocumentFromNode(n).Selection)
+}
+
+// classWeight rewards names that look like content and penalises chrome.
+func classWeight(s *goquery.Selection) float64 {
+	weight := 0.0
+	for _, name := range []string{s.AttrOr("class", ""), s.AttrOr("id", "")} {
+		if name == "" {
+			continue
+		}
+		if negativeNames.MatchString(name) {
+			weight -= 25
+		}
+		if positiveNames.MatchString(name) {
+			weight += 25
+		}
+	}
+	return weight
+}
+
+// linkDensity is the share of a node's text that sits inside links.
+func linkDensity(s *goquery.Selection) float64 {
+	textLen := len(strings.TrimSpace(s.Text()))
+	if textLen == 0 {
+		return 0
+	}
+	linkLen := 0
+	s.Find("a").Each(func(_ int, a *goquery.Selection) {
+		weight := 1.0
+		if strings.HasPrefix(a.AttrOr("href", ""), "#") {
+			weight = 0.3
+		}
+		linkLen += int(float64(len(strings.TrimSpace(a.Text()))) * weight)
+	})
+	return float64(linkLen) / float64(textLen)
+}
+
+// hasBlockChildren reports whether a div contains block-level elements.
+func hasBlockChildren(s *goquery.Selection) bool {
+	found := false
+	s.Find("*").EachWithBreak(func(_ int, c *goquery.Selection) bool {
+		if blockTags[goquery.NodeName(c)] {
+			found = true
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// grabArticle scores the tree and assembles the best candidate with its
+// related siblings into a fresh container.
+func grabArticle(body *goquery.Selection) *goquery.Selection {
+	sc := &scorer{scores: make(map[*html.Node]float64)}
+	var candidates []*html.Node
+
+	body.Find("p, pre, td, blockquote, div, section, li").Each(func(_ int, s *goquery.Selection) {
+		tag := goquery.NodeName(s)
+		if (tag == "div" || tag == "section") && hasBlockChildren(s) {
+			return
+		}
+		if tag == "li" && s.Find("p").Length() > 0 {
+			return
+		}
+
+		text := strings.TrimSpace(s.Text())
+		if len(text) < minParagraphLength {
+			return
+		}
+
+		score := 1.0
+		score += float64(strings.Count(text, ",") + strings.Count(text, "，"))
+		score += math.Min(math.Floor(float64(len(text))/100), 3)
+
+		level := 0
+		for ancestor := s.Nodes[0].Parent; ancestor != nil && level < 5; ancestor = ancestor.Parent {
+			if ancestor.Type != html.ElementNode || ancestor.Data == "html" {
+				break
+			}
+			if _, seen := sc.scores[ancestor]; !seen {
+				candidates = append(candidates, ancestor)
+			}
+			sc.initialize(ancestor)
+
+			// Parent gets the full score, grandparent half, then a third per level.
+			divider := float64(level * 3)
+			if level < 2 {
+				divider = float64(level + 1)
+			}
+			sc.scores[ancestor] += score / divider
+			level++
+		}
+	})
+
+	var top *html.Node
+	topScore := 0.0
+	for _, n := range candidates {
+		sel := goquery.NewDocumentFromNode(n).Selection
+		adjusted := sc.scores[n] * (1 - linkDensity(sel))
+		sc.scores[n] = adjusted
+		if top == nil || adjusted > topScore {
+			top = n
+			topScore = adjusted
+		}
+	}
+
+	if top == nil || top.Data == "body" {
+		// Nothing stood out; fall back to the whole body.
+		return body
+	}
+
+	// A top candidate that is the only child of its parent is usually a
+	// wrapper; climb while the parent holds the same text.
+	for top.Parent != nil && top.Parent.Data != "body" && top.Parent.Type == html.ElementNode {
+		parent := goquery.NewDocumentFromNode(top.Parent).Selection
+		if parent.Children().Length() != 1 {
+			break
+		}
+		top = top.Parent
+	}
+
+	threshold := math.Max(10, topScore*siblingScoreRatio)
+	topClass := goquery.NewDocumentFromNode(top).Selection.AttrOr("class", "")
+
+	container := &html.Node{Type: html.ElementNode, Data: "div"}
+	if top.Parent == nil {
+		container.AppendChild(cloneNode(top))
+		return goquery.NewDocumentFromNode(container).Selection
+	}
+
+	for sib := top.Parent.FirstChild; sib != nil; sib = sib.NextSibling {
+		if sib.Type != html.ElementNode {
+			continue
+		}
+		if sib == top {
+			container.AppendChild(cloneNode(sib))
+			continue
+		}
+
+		sel := goquery.NewDocumentFromNode(sib).Selection
+		bonus := 0.0
+		if topClass != "" && sel.AttrOr("class", "") == topClass {
+			bonus = topScore * 0.2
+		}
+
+		include := false
+		if score, ok := sc.scores[sib]; ok && score+bonus >= threshold {
+			include = true
+		} else if sib.Data == "p" {
+			text := strings.TrimSpace(sel.Text())
+			density := linkDensity(sel)
+			switch {
+			case len(text) > 80 && density < 0.25:
+				include = true
+			case len(text) > 0 && len(text) <= 80 && density == 0 && strings.HasSuffix(text, "."):
+				include = true
+			}
+		}
+
+		if include {
+			container.AppendChild(cloneNode(sib))
+		}
+	}
+
+	return goquery.NewDocumentFromNode(container).Selection
+}
+
+// clean removes leftovers that slipped through scoring: link farms, empty
+// blocks, share widgets and a duplicate of the title heading.
+func clean(content *goquery.Selection, title string) {
+	content.Find("h1, h2").Each(func(_ int, s *goquery.Selection) {
+		if classWeight(s) < 0 || strings.EqualFold(strings.TrimSpace(s.Text()), title) {
+			s.Remove()
+		}
+	})
+
+	// Conditionally drop list/table/div blocks that look like navigation.
+	content.Find("div, section, ul, ol, table").Each(func(_ int, s *goquery.Selection) {
+		if s.Closest("pre, code").Length() > 0 {
+			return
+		}
+		weight := classWeight(s)
+		if weight < 0 {
+			s.Remove()
+			return
+		}
+
+		text := strings.TrimSpace(s.Text())
+		if strings.Count(text, ",") >= 10 {
+			return
+		}
+
+		density := linkDensity(s)
+		images := s.Find("img").Length()
+		paragraphs := s.Find("p").Length()
+		embeds := s.Find("iframe, video, audio").Length()
+
+		switch {
+		case density > 0.5 && weight < 25:
+			s.Remove()
+		case len(text) < minParagraphLength && images == 0 && embeds == 0:
+			s.Remove()
+		case images > 1 && paragraphs > 0 && float64(paragraphs)/float64(images) < 0.5 && goquery.NodeName(s) != "ul" && goquery.NodeName(s) != "ol":
+			s.Remove()
+		}
+	})
+
+	content.Find("p").Each(func(_ int, s *goquery.Selection) {
+		if strings.TrimSpace(s.Text()) == "" && s.Find("img, iframe, video, audio, picture").Length() == 0 {
+			s.Remove()
+		}
+	})
+
+	// Strip presentational attributes; the sanitizer enforces the rest.
+	content.Find("*").Each(func(_ int, s *goquery.Selection) {
+		s.RemoveAttr("style").RemoveAttr("class").RemoveAttr("id").RemoveAttr("align")
+	})
+
+	// Lazy-loading pages often keep the real image in a data attribute.
+	content.Find("img").Each(func(_ int, s *goquery.Selection) {
+		src := s.AttrOr("src", "")
+		if src == "" || strings.HasPrefix(src, "data:") {
+			for _, attr := range []string{"data-src", "data-original", "data-lazy-src"} {
+				if v := s.AttrOr(attr, ""); v != "" {
+					s.SetAttr("src", v)
+					break
+				}
+			}
+		}
+	})
+}
+
+// absolutize rewrites relative href/src attributes against base.
+func absolutize(content *goquery.Selection, base *url.URL) {
+	if base == nil {
+		return
+	}
+	for _, attr := range []string{"href", "src", "poster"} {
+		content.Find("[" + attr + "]").Each(func(_ int, s *goquery.Selection) {
+			val := strings.TrimSpace(s.AttrOr(attr, ""))
+			if strings.HasPrefix(val, "#") || strings.HasPrefix(val, "data:") {
+				return
+			}
+			if ref, err := base.Parse(val); err == nil {
+				s.SetAttr(attr, ref.String())
+			}
+		})
+	}
+	content.Find("[srcset]").Each(func(_ int, s *goquery.Selection) {
+		candidates := strings.Split(s.AttrOr("srcset", ""), ",")
+		for i, candidate := range candidates {
+			fields := strings.Fields(candidate)
+			if len(fields) == 0 {
+				continue
+			}
+			if ref, err := base.Parse(fields[0]); err == nil {
+				fields[0] = ref.String()
+			}
+			candidates[i] = strings.Join(fields, " ")
+		}
+		s.SetAttr("srcset", strings.Join(candidates, ", "))
+	})
+}
+
+// cloneNode deep-copies a node so the result does not share structure with
+// the source document.
+func cloneNode(n *html.Node) *html.Node {
+	clone := &html.Node{
+		Type:      n.Type,
+		DataAtom:  n.DataAtom,
+		Data:      n.Data,
+		Namespace: n.Namespace,
+		Attr:      append([]html.Attribute(nil), n.Attr...),
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		clone.AppendChild(cloneNode(c))
+	}
+	return clone
+}
@@ -0,0 +1,236 @@
+package readability
+
+import (
+	"flag"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+var update = flag.Bool("update", false, "rewrite the expected text of the fixtures")
+
+func loadFixture(t *testing.T, name string) *goquery.Document {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	doc, err := goquery.NewDocumentFromReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func parseHTML(t *testing.T, fragment string) *goquery.Document {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(fragment))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+// TestParseFixtures extracts the saved pages of testdata and compares the
+// structured output; the expected text of page.html is page.txt.
+func TestParseFixtures(t *testing.T) {
+	tests := []struct {
+		fixture   string
+		title     string
+		byline    string
+		siteName  string
+		leadImage string
+		excludes  []string
+	}{
+		{
+			fixture:   "news.html",
+			title:     "City Council Approves New Bike Lanes",
+			byline:    "Maria Lopez",
+			siteName:  "The Daily Ledger",
+			leadImage: "https://example.com/media/bike-lanes.jpg",
+			excludes:  []string{"Most read", "Local bakery", "Great news", "Copyright", "Sports"},
+		},
+		{
+			fixture:   "blog.html",
+			title:     "Notes on Writing a Tiny Parser",
+			byline:    "By Sam Chen",
+			leadImage: "https://example.com/posts/images/parser-diagram.png",
+			excludes:  []string{"Share on Twitter", "compilers"},
+		},
+		{
+			fixture:  "linkfarm.html",
+			title:    "Why Sourdough Needs Time",
+			excludes: []string{"Bread recipes", "pizza dough"},
+		},
+		{
+			fixture:   "multibyte.html",
+			title:     "京都の紅葉、見頃は来週から",
+			byline:    "山田 花子",
+			leadImage: "https://example.jp/img/momiji.jpg",
+		},
+	}
+
+	pageURL, _ := url.Parse("https://example.com/posts/one")
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			article, err := Parse(loadFixture(t, tt.fixture), pageURL)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+
+			if article.Title != tt.title {
+				t.Errorf("Title = %q, want %q", article.Title, tt.title)
+			}
+			if article.Byline != tt.byline {
+				t.Errorf("Byline = %q, want %q", article.Byline, tt.byline)
+			}
+			if article.SiteName != tt.siteName {
+				t.Errorf("SiteName = %q, want %q", article.SiteName, tt.siteName)
+			}
+			if article.LeadImage != tt.leadImage {
+				t.Errorf("LeadImage = %q, want %q", article.LeadImage, tt.leadImage)
+			}
+
+			golden := filepath.Join("testdata", strings.TrimSuffix(tt.fixture, ".html")+".txt")
+			if *update {
+				if err := os.WriteFile(golden, []byte(article.Text+"\n"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if article.Text != strings.TrimSuffix(string(want), "\n") {
+				t.Errorf("Text =\n%s\nwant\n%s", article.Text, want)
+			}
+
+			for _, s := range tt.excludes {
+				if strings.Contains(article.Text, s) || strings.Contains(article.Content, s) {
+					t.Errorf("extracted content contains %q", s)
+				}
+			}
+		})
+	}
+}
+
+func TestParseNoContent(t *testing.T) {
+	doc := parseHTML(t, `<html><head><title>Empty</title></head><body><nav><a href="/">Home</a></nav></body></html>`)
+	if _, err := Parse(doc, nil); err != ErrNoContent {
+		t.Errorf("Parse error = %v, want ErrNoContent", err)
+	}
+}
+
+// TestParseMultibyte checks that non-ASCII text survives extraction intact:
+// the former extractor cut the text at a byte limit, splitting characters.
+func TestParseMultibyte(t *testing.T) {
+	article, err := Parse(loadFixture(t, "multibyte.html"), nil)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	for name, s := range map[string]string{"Text": article.Text, "Content": article.Content, "Title": article.Title} {
+		if !utf8.ValidString(s) {
+			t.Errorf("%s is not valid UTF-8", name)
+		}
+	}
+	if !strings.HasSuffix(article.Text, "確認できます。🍁") {
+		t.Errorf("Text was cut: ends with %q", article.Text[len(article.Text)-30:])
+	}
+}
+
+func TestLinkDensity(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want float64
+	}{
+		{"no links", `<div>plain text only</div>`, 0},
+		{"empty", `<div></div>`, 0},
+		{"all links", `<div><a href="/a">abcde</a><a href="/b">fghij</a></div>`, 1},
+		{"half links", `<div>abcde<a href="/a">fghij</a></div>`, 0.5},
+		{"fragment links weigh less", `<div>abcdefghij<a href="#note">0123456789</a></div>`, 0.15},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sel := parseHTML(t, tt.html).Find("div")
+			if got := linkDensity(sel); got != tt.want {
+				t.Errorf("linkDensity = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassWeight(t *testing.T) {
+	tests := []struct {
+		html string
+		want float64
+	}{
+		{`<div>x</div>`, 0},
+		{`<div class="article-body">x</div>`, 25},
+		{`<div id="content">x</div>`, 25},
+		{`<div class="sidebar">x</div>`, -25},
+		{`<div class="comment-list">x</div>`, -25},
+		{`<div class="post" id="footer">x</div>`, 0},
+		{`<div class="entry-content" id="main">x</div>`, 50},
+		{`<div class="post-share">x</div>`, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.html, func(t *testing.T) {
+			sel := parseHTML(t, tt.html).Find("div")
+			if got := classWeight(sel); got != tt.want {
+				t.Errorf("classWeight = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestScoringPrefersContent checks that class/id names and link density
+// decide between otherwise similar candidates.
+func TestScoringPrefersContent(t *testing.T) {
+	paragraph := "<p>This paragraph is long enough to be scored, with a few commas, clauses, and words in it.</p>"
+
+	tests := []struct {
+		name    string
+		html    string
+		want    string
+		notWant string
+	}{
+		{
+			name:    "positive class wins",
+			html:    `<body><div class="promo">` + strings.Repeat(paragraph, 3) + `<p>PROMO</p></div><div class="story">` + strings.Repeat(paragraph, 3) + `<p>STORY TEXT ends here.</p></div></body>`,
+			want:    "STORY TEXT",
+			notWant: "PROMO",
+		},
+		{
+			name:    "link-dense block loses",
+			html:    `<body><div class="block"><p><a href="/1">` + strings.Repeat("linked words, more linked words, ", 10) + `</a>LINKS</p></div><div class="block">` + strings.Repeat(paragraph, 2) + `<p>PROSE continues here.</p></div></body>`,
+			want:    "PROSE",
+			notWant: "LINKS",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			article, err := Parse(parseHTML(t, tt.html), nil)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if !strings.Contains(article.Text, tt.want) {
+				t.Errorf("Text does not contain %q:\n%s", tt.want, article.Text)
+			}
+			if strings.Contains(article.Text, tt.notWant) {
+				t.Errorf("Text contains %q:\n%s", tt.notWant, article.Text)
+			}
+		})
+	}
+}
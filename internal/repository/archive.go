@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/michael/flowreader/internal/domain"
+)
+
+// ArchiveRepository implements domain.ArchiveRepository using PostgreSQL.
+type ArchiveRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewArchiveRepository creates a new article archive repository.
+func NewArchiveRepository(pool *pgxpool.Pool) *ArchiveRepository {
+	return &ArchiveRepository{pool: pool}
+}
+
+// Save stores an archive, replacing any previous snapshot of the article.
+func (r *ArchiveRepository) Save(archive *domain.ArticleArchive) error {
+	ctx := context.Background()
+
+	query := `
+		INSERT INTO article_archives (article_id, user_id, title, url, author, published_at, content,
+		                              source, image_count, failed_image_count, size_bytes, archived_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (article_id) DO UPDATE
+		SET title = EXCLUDED.title, url = EXCLUDED.url, author = EXCLUDED.author,
+		    published_at = EXCLUDED.published_at, content = EXCLUDED.content, source = EXCLUDED.source,
+		    image_count = EXCLUDED.image_count, failed_image_count = EXCLUDED.failed_image_count,
+		    size_bytes = EXCLUDED.size_bytes, archived_at = EXCLUDED.archived_at
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		archive.ArticleID,
+		archive.UserID,
+		archive.Title,
+		nullString(archive.URL),
+		nullString(archive.Author),
+		archive.PublishedAt,
+		archive.Content,
+		string(archive.Source),
+		archive.ImageCount,
+		archive.FailedImageCount,
+		archive.SizeBytes,
+		archive.ArchivedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("saving archive: %w", err)
+	}
+
+	return nil
+}
+
+// Get retrieves the archive of an article.
+func (r *ArchiveRepository) Get(articleID uuid.UUID) (*domain.ArticleArchive, error) {
+	ctx := context.Background()
+
+	query := `
+		SELECT article_id, user_id, title, url, author, published_at, content,
+		       source, image_count, failed_image_count, size_bytes, archived_at
+		FROM article_archives
+		WHERE article_id = $1
+	`
+
+	var archive domain.ArticleArchive
+	var url, author *string
+	var source string
+	err := r.pool.QueryRow(ctx, query, articleID).Scan(
+		&archive.ArticleID,
+		&archive.UserID,
+		&archive.Title,
+		&url,
+		&author,
+		&archive.PublishedAt,
+		&archive.Content,
+		&source,
+		&archive.ImageCount,
+		&archive.FailedImageCount,
+		&archive.SizeBytes,
+		&archive.ArchivedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting archive: %w", err)
+	}
+
+	if url != nil {
+		archive.URL = *url
+	}
+	if author != nil {
+		archive.Author = *author
+	}
+	archive.Source = domain.ArchiveSource(source)
+
+	return &archive, nil
+}
+
+// Delete removes the archive of an article, reporting whether one existed.
+func (r *ArchiveRepository) Delete(articleID uuid.UUID) (bool, error) {
+	ctx := context.Background()
+
+	result, err := r.pool.Exec(ctx, "DELETE FROM article_archives WHERE article_id = $1", articleID)
+	if err != nil {
+		return false, fmt.Errorf("deleting archive: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// PendingFavorites returns favorite articles that have not been archived
+// yet, oldest favorites first.
+func (r *ArchiveRepository) PendingFavorites(limit int) ([]uuid.UUID, error) {
+	ctx := context.Background()
+
+	query := `
+		SELECT a.id
+		FROM articles a
+		WHERE a.is_favorite = true
+		  AND NOT EXISTS (SELECT 1 FROM article_archives aa WHERE aa.article_id = a.id)
+		ORDER BY a.favorited_at ASC NULLS FIRST, a.id
+		LIMIT $1
+	`
+
+	rows, err := r.pool.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("querying pending favorites: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scanning article ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"html"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/michael/flowreader/internal/domain"
+	"github.com/michael/flowreader/internal/utils"
 )
 
 // ArticleRepository implements domain.ArticleRepository using PostgreSQL.
@@ -27,8 +30,16 @@ func (r *ArticleRepository) Create(article *domain.Article) error {
 	ctx := context.Background()
 
 	query := `
-		INSERT INTO articles (id, feed_id, guid, title, url, content, summary, ai_summary, author, image_url, published_at, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		INSERT INTO articles (id, feed_id, guid, title, url, content, summary, ai_summary, author, image_url, published_at, created_at, sanitizer_version, language,
+		                      content_raw, summary_raw)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $17, $18
+		-- Same canonical URL in the same feed is a duplicate even if the GUID
+		-- changed; the GUID and URL as published ($15, $16) match articles
+		-- stored before tracking parameters were stripped
+		WHERE NOT EXISTS (
+			SELECT 1 FROM articles
+			WHERE feed_id = $2 AND (url = $5 OR guid = $15 OR url = $16)
+		)
 		ON CONFLICT (feed_id, guid) DO NOTHING
 	`
 
@@ -45,6 +56,12 @@ func (r *ArticleRepository) Create(article *domain.Article) error {
 		nullString(article.ImageURL),
 		article.PublishedAt,
 		article.CreatedAt,
+		article.SanitizerVersion,
+		nullString(article.Language),
+		sourceGUID(article),
+		nullString(article.SourceURL),
+		nullString(article.RawContent),
+		nullString(article.RawSummary),
 	)
 
 	if err != nil {
@@ -54,14 +71,30 @@ func (r *ArticleRepository) Create(article *domain.Article) error {
 	return nil
 }
 
+// sourceGUID returns the GUID the feed published for an article.
+func sourceGUID(article *domain.Article) string {
+	if article.SourceGUID != "" {
+		return article.SourceGUID
+	}
+	return article.GUID
+}
+
 // CreateBatch inserts multiple articles into the database.
 func (r *ArticleRepository) CreateBatch(articles []*domain.Article) error {
 	ctx := context.Background()
 
 	batch := &pgx.Batch{}
 	query := `
-		INSERT INTO articles (id, feed_id, guid, title, url, content, summary, ai_summary, author, image_url, published_at, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		INSERT INTO articles (id, feed_id, guid, title, url, content, summary, ai_summary, author, image_url, published_at, created_at, sanitizer_version, language,
+		                      content_raw, summary_raw)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $17, $18
+		-- Same canonical URL in the same feed is a duplicate even if the GUID
+		-- changed; the GUID and URL as published ($15, $16) match articles
+		-- stored before tracking parameters were stripped
+		WHERE NOT EXISTS (
+			SELECT 1 FROM articles
+			WHERE feed_id = $2 AND (url = $5 OR guid = $15 OR url = $16)
+		)
 		ON CONFLICT (feed_id, guid) DO NOTHING
 	`
 
@@ -79,6 +112,34 @@ func (r *ArticleRepository) CreateBatch(articles []*domain.Article) error {
 			nullString(article.ImageURL),
 			article.PublishedAt,
 			article.CreatedAt,
+			article.SanitizerVersion,
+			nullString(article.Language),
+			sourceGUID(article),
+			nullString(article.SourceURL),
+			nullString(article.RawContent),
+			nullString(article.RawSummary),
+		)
+	}
+
+	// Articles stored before the HTML as received was kept get it, and the
+	// HTML sanitized from it, from the feed while it still lists them
+	backfill := `
+		UPDATE articles
+		SET content_raw = $4, summary_raw = $5, content = $6, summary = $7, sanitizer_version = $8
+		WHERE feed_id = $1 AND (guid = $2 OR guid = $3)
+		  AND content_raw IS NULL AND summary_raw IS NULL
+		  AND ($4::text IS NOT NULL OR $5::text IS NOT NULL)
+	`
+	for _, article := range articles {
+		batch.Queue(backfill,
+			article.FeedID,
+			article.GUID,
+			sourceGUID(article),
+			nullString(article.RawContent),
+			nullString(article.RawSummary),
+			nullString(article.Content),
+			nullString(article.Summary),
+			article.SanitizerVersion,
 		)
 	}
 
@@ -90,6 +151,11 @@ func (r *ArticleRepository) CreateBatch(articles []*domain.Article) error {
 			return fmt.Errorf("batch insert: %w", err)
 		}
 	}
+	for range articles {
+		if _, err := results.Exec(); err != nil {
+			return fmt.Errorf("backfilling raw HTML: %w", err)
+		}
+	}
 
 	return nil
 }
@@ -101,7 +167,8 @@ func (r *ArticleRepository) GetByID(id uuid.UUID) (*domain.Article, error) {
 	query := `
 		SELECT a.id, a.feed_id, a.guid, a.title, a.url, a.content, a.summary, a.ai_summary, a.author, 
 		       a.image_url, a.published_at, a.is_read, a.is_favorite, a.read_at, a.created_at,
-		       f.title as feed_title
+		       a.sanitizer_version, a.favorited_at, a.language, ` + articleTagsColumn + `,
+		       EXISTS (SELECT 1 FROM read_later rl WHERE rl.article_id = a.id) AS read_later, f.title as feed_title
 		FROM articles a
 		JOIN feeds f ON f.id = a.feed_id
 		WHERE a.id = $1
@@ -119,65 +186,18 @@ func (r *ArticleRepository) GetByID(id uuid.UUID) (*domain.Article, error) {
 }
 
 // GetByFeedID retrieves articles for a specific feed.
-func (r *ArticleRepository) GetByFeedID(feedID uuid.UUID, limit, offset int) ([]*domain.Article, error) {
-	ctx := context.Background()
-
-	query := `
-		SELECT a.id, a.feed_id, a.guid, a.title, a.url, a.content, a.summary, a.ai_summary, a.author,
-		       a.image_url, a.published_at, a.is_read, a.is_favorite, a.read_at, a.created_at,
-		       f.title as feed_title
-		FROM articles a
-		JOIN feeds f ON f.id = a.feed_id
-		WHERE a.feed_id = $1
-		ORDER BY a.published_at DESC NULLS LAST, a.created_at DESC
-		LIMIT $2 OFFSET $3
-	`
-
-	rows, err := r.pool.Query(ctx, query, feedID, limit, offset)
-	if err != nil {
-		return nil, fmt.Errorf("querying articles: %w", err)
-	}
-	defer rows.Close()
-
-	return r.scanArticles(rows)
+func (r *ArticleRepository) GetByFeedID(feedID uuid.UUID, page domain.Page) ([]*domain.Article, error) {
+	return r.Query(domain.ArticleFilter{FeedIDs: []uuid.UUID{feedID}}, page)
 }
 
 // GetByUserID retrieves articles for all user's feeds.
-func (r *ArticleRepository) GetByUserID(userID uuid.UUID, limit, offset int, unreadOnly bool) ([]*domain.Article, error) {
-	ctx := context.Background()
-
-	var query string
+func (r *ArticleRepository) GetByUserID(userID uuid.UUID, page domain.Page, unreadOnly bool) ([]*domain.Article, error) {
+	filter := domain.ArticleFilter{UserID: userID}
 	if unreadOnly {
-		query = `
-			SELECT a.id, a.feed_id, a.guid, a.title, a.url, a.content, a.summary, a.ai_summary, a.author,
-			       a.image_url, a.published_at, a.is_read, a.is_favorite, a.read_at, a.created_at,
-			       f.title as feed_title
-			FROM articles a
-			JOIN feeds f ON f.id = a.feed_id
-			WHERE f.user_id = $1 AND a.is_read = false
-			ORDER BY a.published_at DESC NULLS LAST, a.created_at DESC
-			LIMIT $2 OFFSET $3
-		`
-	} else {
-		query = `
-			SELECT a.id, a.feed_id, a.guid, a.title, a.url, a.content, a.summary, a.ai_summary, a.author,
-			       a.image_url, a.published_at, a.is_read, a.is_favorite, a.read_at, a.created_at,
-			       f.title as feed_title
-			FROM articles a
-			JOIN feeds f ON f.id = a.feed_id
-			WHERE f.user_id = $1
-			ORDER BY a.published_at DESC NULLS LAST, a.created_at DESC
-			LIMIT $2 OFFSET $3
-		`
+		isRead := false
+		filter.IsRead = &isRead
 	}
-
-	rows, err := r.pool.Query(ctx, query, userID, limit, offset)
-	if err != nil {
-		return nil, fmt.Errorf("querying articles: %w", err)
-	}
-	defer rows.Close()
-
-	return r.scanArticles(rows)
+	return r.Query(filter, page)
 }
 
 // GetByGUID retrieves an article by its GUID within a feed.
@@ -187,7 +207,8 @@ func (r *ArticleRepository) GetByGUID(feedID uuid.UUID, guid string) (*domain.Ar
 	query := `
 		SELECT a.id, a.feed_id, a.guid, a.title, a.url, a.content, a.summary, a.ai_summary, a.author,
 		       a.image_url, a.published_at, a.is_read, a.is_favorite, a.read_at, a.created_at,
-		       f.title as feed_title
+		       a.sanitizer_version, a.favorited_at, a.language, ` + articleTagsColumn + `,
+		       EXISTS (SELECT 1 FROM read_later rl WHERE rl.article_id = a.id) AS read_later, f.title as feed_title
 		FROM articles a
 		JOIN feeds f ON f.id = a.feed_id
 		WHERE a.feed_id = $1 AND a.guid = $2
@@ -207,7 +228,22 @@ func (r *ArticleRepository) GetByGUID(feedID uuid.UUID, guid string) (*domain.Ar
 // MarkAsRead marks an article as read.
 func (r *ArticleRepository) MarkAsRead(id uuid.UUID) error {
 	ctx := context.Background()
-	query := `UPDATE articles SET is_read = true, read_at = $2 WHERE id = $1`
+
+	// Record a read event in the reading history when the article was unread
+	query := `
+		WITH prev AS (
+			SELECT a.id, a.feed_id, f.user_id, a.is_read
+			FROM articles a
+			JOIN feeds f ON f.id = a.feed_id
+			WHERE a.id = $1
+		), updated AS (
+			UPDATE articles SET is_read = true, read_at = $2 WHERE id = $1
+		)
+		INSERT INTO reading_events (user_id, article_id, feed_id, event_type, created_at)
+		SELECT user_id, id, feed_id, 'read', $2
+		FROM prev
+		WHERE NOT is_read
+	`
 	_, err := r.pool.Exec(ctx, query, id, time.Now())
 	if err != nil {
 		return fmt.Errorf("marking as read: %w", err)
@@ -228,10 +264,7 @@ func (r *ArticleRepository) MarkAsUnread(id uuid.UUID) error {
 
 // MarkAllAsRead marks all articles in a feed as read.
 func (r *ArticleRepository) MarkAllAsRead(feedID uuid.UUID) error {
-	ctx := context.Background()
-	query := `UPDATE articles SET is_read = true, read_at = $2 WHERE feed_id = $1 AND is_read = false`
-	_, err := r.pool.Exec(ctx, query, feedID, time.Now())
-	if err != nil {
+	if _, err := r.MarkAsReadMatching(domain.ArticleFilter{FeedIDs: []uuid.UUID{feedID}}); err != nil {
 		return fmt.Errorf("marking all as read: %w", err)
 	}
 	return nil
@@ -239,55 +272,200 @@ func (r *ArticleRepository) MarkAllAsRead(feedID uuid.UUID) error {
 
 // MarkAllAsReadGlobal marks all articles for a user as read.
 func (r *ArticleRepository) MarkAllAsReadGlobal(userID uuid.UUID) error {
+	if _, err := r.MarkAsReadMatching(domain.ArticleFilter{UserID: userID}); err != nil {
+		return fmt.Errorf("marking all articles as read globally: %w", err)
+	}
+	return nil
+}
+
+// MarkAsReadMatching marks the unread articles matching the filter as read
+// and returns how many were updated.
+func (r *ArticleRepository) MarkAsReadMatching(filter domain.ArticleFilter) (int64, error) {
 	ctx := context.Background()
 
+	filter, err := r.withSearchLanguages(filter, filter.UserID)
+	if err != nil {
+		return 0, err
+	}
+
+	isRead := false
+	filter.IsRead = &isRead
+	where, args := articleFilterClause(filter, nil)
+
+	// The filter only matches unread articles, so each updated one gets a
+	// read event in the reading history
 	query := `
-		UPDATE articles
-		SET is_read = true, read_at = NOW()
-		WHERE feed_id IN (SELECT id FROM feeds WHERE user_id = $1) AND is_read = false
+		WITH updated AS (
+			UPDATE articles
+			SET is_read = true, read_at = NOW()
+			WHERE id IN (
+				SELECT a.id
+				FROM articles a
+				JOIN feeds f ON f.id = a.feed_id
+				WHERE ` + where + `
+			)
+			RETURNING id, feed_id
+		)
+		INSERT INTO reading_events (user_id, article_id, feed_id, event_type, created_at)
+		SELECT f.user_id, u.id, u.feed_id, 'read', NOW()
+		FROM updated u
+		JOIN feeds f ON f.id = u.feed_id
 	`
 
-	_, err := r.pool.Exec(ctx, query, userID)
+	tag, err := r.pool.Exec(ctx, query, args...)
 	if err != nil {
-		return fmt.Errorf("marking all articles as read globally: %w", err)
+		return 0, fmt.Errorf("marking matching articles as read: %w", err)
 	}
 
-	return nil
+	return tag.RowsAffected(), nil
 }
 
-// ToggleFavorite toggles the favorite status of an article.
-func (r *ArticleRepository) ToggleFavorite(id uuid.UUID) error {
+// UnreadCounts returns the number of unread articles per feed of a user.
+// Feeds without unread articles are included with a zero count.
+func (r *ArticleRepository) UnreadCounts(userID uuid.UUID) (map[uuid.UUID]int, error) {
 	ctx := context.Background()
-	query := `UPDATE articles SET is_favorite = NOT is_favorite WHERE id = $1`
-	_, err := r.pool.Exec(ctx, query, id)
+
+	query := `
+		SELECT f.id, COUNT(a.id)
+		FROM feeds f
+		LEFT JOIN articles a ON a.feed_id = f.id AND a.is_read = false
+		WHERE f.user_id = $1
+		GROUP BY f.id
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID)
 	if err != nil {
-		return fmt.Errorf("toggling favorite: %w", err)
+		return nil, fmt.Errorf("counting unread articles: %w", err)
 	}
-	return nil
+	defer rows.Close()
+
+	counts := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var feedID uuid.UUID
+		var count int
+		if err := rows.Scan(&feedID, &count); err != nil {
+			return nil, fmt.Errorf("scanning unread count: %w", err)
+		}
+		counts[feedID] = count
+	}
+
+	return counts, rows.Err()
 }
 
-// GetFavorites retrieves favorited articles for a user.
-func (r *ArticleRepository) GetFavorites(userID uuid.UUID, limit, offset int) ([]*domain.Article, error) {
+// ToggleFavorite toggles the favorite status of an article and returns the
+// resulting state.
+func (r *ArticleRepository) ToggleFavorite(id uuid.UUID) (bool, *time.Time, error) {
 	ctx := context.Background()
+	query := `
+		UPDATE articles
+		SET is_favorite = NOT is_favorite,
+		    favorited_at = CASE WHEN is_favorite THEN NULL ELSE NOW() END
+		WHERE id = $1
+		RETURNING is_favorite, favorited_at
+	`
 
+	var isFavorite bool
+	var favoritedAt *time.Time
+	if err := r.pool.QueryRow(ctx, query, id).Scan(&isFavorite, &favoritedAt); err != nil {
+		return false, nil, fmt.Errorf("toggling favorite: %w", err)
+	}
+	return isFavorite, favoritedAt, nil
+}
+
+// SetFavorite stars or unstars an article and returns the resulting state.
+// Starring an already starred article keeps its original favorited_at.
+func (r *ArticleRepository) SetFavorite(id uuid.UUID, favorite bool) (bool, *time.Time, error) {
+	ctx := context.Background()
 	query := `
-		SELECT a.id, a.feed_id, a.guid, a.title, a.url, a.content, a.summary, a.ai_summary, a.author,
-		       a.image_url, a.published_at, a.is_read, a.is_favorite, a.read_at, a.created_at,
-		       f.title as feed_title
-		FROM articles a
-		JOIN feeds f ON f.id = a.feed_id
-		WHERE f.user_id = $1 AND a.is_favorite = true
-		ORDER BY a.published_at DESC NULLS LAST, a.created_at DESC
-		LIMIT $2 OFFSET $3
+		UPDATE articles
+		SET is_favorite = $2,
+		    favorited_at = CASE WHEN $2 THEN COALESCE(favorited_at, NOW()) ELSE NULL END
+		WHERE id = $1
+		RETURNING is_favorite, favorited_at
 	`
 
-	rows, err := r.pool.Query(ctx, query, userID, limit, offset)
+	var isFavorite bool
+	var favoritedAt *time.Time
+	if err := r.pool.QueryRow(ctx, query, id, favorite).Scan(&isFavorite, &favoritedAt); err != nil {
+		return false, nil, fmt.Errorf("setting favorite: %w", err)
+	}
+	return isFavorite, favoritedAt, nil
+}
+
+// BatchUpdate applies a state operation to the given articles owned by the
+// user in a single statement and returns the IDs that were updated. IDs that
+// do not exist or belong to another user are ignored.
+func (r *ArticleRepository) BatchUpdate(userID uuid.UUID, ids []uuid.UUID, op domain.ArticleBatchOp) ([]uuid.UUID, error) {
+	ctx := context.Background()
+
+	var set string
+	switch op {
+	case domain.BatchRead:
+		set = "is_read = true, read_at = COALESCE(a.read_at, NOW())"
+	case domain.BatchUnread:
+		set = "is_read = false, read_at = NULL"
+	case domain.BatchFavorite:
+		set = "is_favorite = true, favorited_at = COALESCE(a.favorited_at, NOW())"
+	case domain.BatchUnfavorite:
+		set = "is_favorite = false, favorited_at = NULL"
+	default:
+		return nil, fmt.Errorf("unsupported batch operation: %s", op)
+	}
+
+	query := `
+		UPDATE articles a
+		SET ` + set + `
+		FROM feeds f
+		WHERE f.id = a.feed_id AND f.user_id = $1 AND a.id = ANY($2)
+		RETURNING a.id
+	`
+	if op == domain.BatchRead {
+		// Record a read event for the articles that were unread, as
+		// MarkAsRead does
+		query = `
+			WITH prev AS (
+				SELECT a.id, a.feed_id, a.is_read
+				FROM articles a
+				JOIN feeds f ON f.id = a.feed_id
+				WHERE f.user_id = $1 AND a.id = ANY($2)
+			), updated AS (
+				UPDATE articles a
+				SET ` + set + `
+				FROM prev
+				WHERE a.id = prev.id
+				RETURNING a.id
+			), events AS (
+				INSERT INTO reading_events (user_id, article_id, feed_id, event_type, created_at)
+				SELECT $1, id, feed_id, 'read', NOW()
+				FROM prev
+				WHERE NOT is_read
+			)
+			SELECT id FROM updated
+		`
+	}
+
+	rows, err := r.pool.Query(ctx, query, userID, ids)
 	if err != nil {
-		return nil, fmt.Errorf("querying favorites: %w", err)
+		return nil, fmt.Errorf("batch updating articles: %w", err)
 	}
 	defer rows.Close()
 
-	return r.scanArticles(rows)
+	var updated []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scanning updated article: %w", err)
+		}
+		updated = append(updated, id)
+	}
+
+	return updated, rows.Err()
+}
+
+// GetFavorites retrieves favorited articles for a user.
+func (r *ArticleRepository) GetFavorites(userID uuid.UUID, page domain.Page) ([]*domain.Article, error) {
+	isFavorite := true
+	return r.Query(domain.ArticleFilter{UserID: userID, IsFavorite: &isFavorite}, page)
 }
 
 // CountUnread counts unread articles for a feed.
@@ -307,7 +485,7 @@ func (r *ArticleRepository) CountUnread(feedID uuid.UUID) (int, error) {
 // scanArticle scans a single article row.
 func (r *ArticleRepository) scanArticle(row pgx.Row) (*domain.Article, error) {
 	var article domain.Article
-	var url, content, summary, aiSummary, author, imageURL, feedTitle *string
+	var url, content, summary, aiSummary, author, imageURL, language, feedTitle *string
 	var publishedAt, readAt *time.Time
 
 	err := row.Scan(
@@ -326,6 +504,11 @@ func (r *ArticleRepository) scanArticle(row pgx.Row) (*domain.Article, error) {
 		&article.IsFavorite,
 		&readAt,
 		&article.CreatedAt,
+		&article.SanitizerVersion,
+		&article.FavoritedAt,
+		&language,
+		&article.Tags,
+		&article.ReadLater,
 		&feedTitle,
 	)
 
@@ -351,6 +534,9 @@ func (r *ArticleRepository) scanArticle(row pgx.Row) (*domain.Article, error) {
 	if imageURL != nil {
 		article.ImageURL = *imageURL
 	}
+	if language != nil {
+		article.Language = *language
+	}
 	if feedTitle != nil {
 		article.FeedTitle = *feedTitle
 	}
@@ -360,101 +546,499 @@ func (r *ArticleRepository) scanArticle(row pgx.Row) (*domain.Article, error) {
 	return &article, nil
 }
 
-// scanArticles scans multiple article rows.
-func (r *ArticleRepository) scanArticles(rows pgx.Rows) ([]*domain.Article, error) {
-	var articles []*domain.Article
-	for rows.Next() {
-		var article domain.Article
-		var url, content, summary, aiSummary, author, imageURL, feedTitle *string
-		var publishedAt, readAt *time.Time
+// Search performs a full-text search on articles for a specific user.
+func (r *ArticleRepository) Search(userID uuid.UUID, query string, page domain.Page) ([]*domain.Article, error) {
+	return r.Query(domain.ArticleFilter{UserID: userID, Query: query, Sort: domain.SortRelevance}, page)
+}
 
-		err := rows.Scan(
-			&article.ID,
-			&article.FeedID,
-			&article.GUID,
-			&article.Title,
+// Query retrieves a page of articles matching the filter.
+func (r *ArticleRepository) Query(filter domain.ArticleFilter, page domain.Page) ([]*domain.Article, error) {
+	ctx := context.Background()
+
+	filter, err := r.withSearchLanguages(filter, filter.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	where, args := articleFilterClause(filter, nil)
+
+	rankExpr := searchRank(filter)
+
+	sort := filter.Sort
+	if sort == "" || (sort == domain.SortRelevance && filter.Query == "") {
+		sort = domain.SortNewest
+	}
+
+	keyset, pagination, args := pageClauses(page, sort, rankExpr, args)
+
+	sql := `
+		SELECT a.id, a.feed_id, a.guid, a.title, a.url, a.content, a.summary, a.ai_summary, a.author,
+		       a.image_url, a.published_at, a.is_read, a.is_favorite, a.read_at, a.created_at,
+		       a.sanitizer_version, a.favorited_at, a.language, ` + articleTagsColumn + `,
+		       EXISTS (SELECT 1 FROM read_later rl WHERE rl.article_id = a.id) AS read_later, f.title as feed_title,
+		       ` + rankExpr + ` as rank
+		FROM articles a
+		JOIN feeds f ON f.id = a.feed_id
+		WHERE ` + where + keyset + `
+		ORDER BY ` + articleOrder(sort, rankExpr) + pagination
+
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying articles: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanArticlesWithRank(rows)
+}
+
+// searchRankExpr ranks an article against the full-text query ($1) parsed
+// with the article's own text search configuration.
+const searchRankExpr = "ts_rank_cd(a.tsv, websearch_to_tsquery(article_ts_config(a.language), $1))"
+
+// fuzzySearchRankExpr adds the trigram similarity of the title to the
+// full-text rank, so that typo matches rank below exact ones.
+const fuzzySearchRankExpr = "(" + searchRankExpr + " + word_similarity($1, a.title))"
+
+// searchRank returns the rank expression of a filter. The full-text query is
+// always $1 when set (see articleFilterClause).
+func searchRank(filter domain.ArticleFilter) string {
+	switch {
+	case filter.Query == "":
+		return "0::real"
+	case filter.Fuzzy:
+		return fuzzySearchRankExpr
+	default:
+		return searchRankExpr
+	}
+}
+
+// withSearchLanguages sets the languages a full-text query is expanded over
+// to those of the user's feeds, unless the filter already has them.
+func (r *ArticleRepository) withSearchLanguages(filter domain.ArticleFilter, userID uuid.UUID) (domain.ArticleFilter, error) {
+	if filter.Query == "" || len(filter.Languages) > 0 || userID == uuid.Nil {
+		return filter, nil
+	}
+
+	ctx := context.Background()
+
+	query := `SELECT ARRAY(SELECT DISTINCT unnest(languages) FROM feeds WHERE user_id = $1)`
+
+	var languages []string
+	if err := r.pool.QueryRow(ctx, query, userID).Scan(&languages); err != nil {
+		return filter, fmt.Errorf("getting search languages: %w", err)
+	}
+	filter.Languages = languages
+
+	return filter, nil
+}
+
+// Markers delimiting matched terms in ts_headline output. They are private
+// use code points, so they cannot collide with the HTML escaping applied to
+// the snippet afterwards.
+const (
+	headlineStart = "\uE000"
+	headlineStop  = "\uE001"
+)
+
+// ts_headline options for the title (whole text) and the body snippet.
+var (
+	titleHeadlineOptions = "HighlightAll=true, StartSel=" + headlineStart + ", StopSel=" + headlineStop
+	bodyHeadlineOptions  = `MaxFragments=2, MaxWords=35, MinWords=15, ShortWord=3, FragmentDelimiter=" … ", StartSel=` + headlineStart + ", StopSel=" + headlineStop
+)
+
+// SearchResults retrieves a page of full-text search hits in their
+// lightweight form, with highlighted title and body snippet. Without a
+// full-text query the snippet is the beginning of the body.
+func (r *ArticleRepository) SearchResults(filter domain.ArticleFilter, page domain.Page) ([]*domain.SearchResult, error) {
+	ctx := context.Background()
+
+	filter, err := r.withSearchLanguages(filter, filter.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	where, args := articleFilterClause(filter, nil)
+	rankExpr := searchRank(filter)
+
+	sort := filter.Sort
+	if sort == "" || (sort == domain.SortRelevance && filter.Query == "") {
+		sort = domain.SortNewest
+	}
+
+	keyset, pagination, args := pageClauses(page, sort, rankExpr, args)
+
+	// Headlines are expensive: compute them on the selected page only. The
+	// body is stripped of its markup so that snippets are plain text.
+	plainBody := `regexp_replace(a.body, '<[^>]*>', ' ', 'g')`
+	titleExpr, snippetExpr := "a.title", "left("+plainBody+", 300)"
+	if filter.Query != "" {
+		args = append(args, titleHeadlineOptions, bodyHeadlineOptions)
+		titleExpr = fmt.Sprintf("ts_headline(article_ts_config(a.language), a.title, websearch_to_tsquery(article_ts_config(a.language), $1), $%d)", len(args)-1)
+		snippetExpr = fmt.Sprintf("ts_headline(article_ts_config(a.language), %s, websearch_to_tsquery(article_ts_config(a.language), $1), $%d)", plainBody, len(args))
+	}
+
+	sql := `
+		SELECT a.id, a.feed_id, a.title, a.url, a.author, a.image_url, a.published_at,
+		       a.is_read, a.is_favorite, a.created_at, a.tags, a.read_later, a.feed_title, a.rank,
+		       ` + titleExpr + `,
+		       ` + snippetExpr + `
+		FROM (
+			SELECT a.id, a.feed_id, a.title, a.url, a.author, a.image_url, a.published_at,
+			       a.is_read, a.is_favorite, a.created_at, a.language, ` + articleTagsColumn + `,
+			       EXISTS (SELECT 1 FROM read_later rl WHERE rl.article_id = a.id) AS read_later,
+			       f.title AS feed_title, ` + rankExpr + ` AS rank,
+			       COALESCE(NULLIF(a.content, ''), a.summary, '') AS body
+			FROM articles a
+			JOIN feeds f ON f.id = a.feed_id
+			WHERE ` + where + keyset + `
+			ORDER BY ` + articleOrder(sort, rankExpr) + pagination + `
+		) a
+		ORDER BY ` + articleOrder(sort, "a.rank")
+
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("searching articles: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*domain.SearchResult
+	for rows.Next() {
+		var res domain.SearchResult
+		var url, author, imageURL, feedTitle *string
+		var titleHeadline, snippet string
+
+		if err := rows.Scan(
+			&res.ID,
+			&res.FeedID,
+			&res.Title,
 			&url,
-			&content,
-			&summary,
-			&aiSummary,
 			&author,
 			&imageURL,
-			&publishedAt,
-			&article.IsRead,
-			&article.IsFavorite,
-			&readAt,
-			&article.CreatedAt,
+			&res.PublishedAt,
+			&res.IsRead,
+			&res.IsFavorite,
+			&res.CreatedAt,
+			&res.Tags,
+			&res.ReadLater,
 			&feedTitle,
-		)
-
-		if err != nil {
-			return nil, fmt.Errorf("scanning article: %w", err)
+			&res.Rank,
+			&titleHeadline,
+			&snippet,
+		); err != nil {
+			return nil, fmt.Errorf("scanning search result: %w", err)
 		}
 
 		if url != nil {
-			article.URL = *url
-		}
-		if content != nil {
-			article.Content = *content
-		}
-		if summary != nil {
-			article.Summary = *summary
-		}
-		if aiSummary != nil {
-			article.AISummary = *aiSummary
+			res.URL = *url
 		}
 		if author != nil {
-			article.Author = *author
+			res.Author = *author
 		}
 		if imageURL != nil {
-			article.ImageURL = *imageURL
+			res.ImageURL = *imageURL
 		}
 		if feedTitle != nil {
-			article.FeedTitle = *feedTitle
+			res.FeedTitle = *feedTitle
 		}
-		article.PublishedAt = publishedAt
-		article.ReadAt = readAt
+		res.TitleHighlight = headlineHTML(titleHeadline)
+		res.Snippet = headlineHTML(snippet)
 
-		articles = append(articles, &article)
+		results = append(results, &res)
 	}
 
-	return articles, nil
+	return results, rows.Err()
 }
 
-// Search performs a full-text search on articles for a specific user.
-func (r *ArticleRepository) Search(userID uuid.UUID, query string, limit, offset int) ([]*domain.Article, error) {
+// headlineHTML turns ts_headline output into safe HTML: entities left over
+// from the stripped markup are decoded, the text is escaped and the match
+// markers become <mark> elements.
+func headlineHTML(headline string) string {
+	text := strings.Join(strings.Fields(html.UnescapeString(headline)), " ")
+	text = html.EscapeString(text)
+	text = strings.ReplaceAll(text, headlineStart, "<mark>")
+	return strings.ReplaceAll(text, headlineStop, "</mark>")
+}
+
+// Count returns the number of articles matching the filter.
+func (r *ArticleRepository) Count(filter domain.ArticleFilter) (int, error) {
 	ctx := context.Background()
 
-	// Use plainto_tsquery or websearch_to_tsquery for natural language search
+	filter, err := r.withSearchLanguages(filter, filter.UserID)
+	if err != nil {
+		return 0, err
+	}
+
+	where, args := articleFilterClause(filter, nil)
 	sql := `
+		SELECT COUNT(*)
+		FROM articles a
+		JOIN feeds f ON f.id = a.feed_id
+		WHERE ` + where
+
+	var count int
+	if err := r.pool.QueryRow(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("counting articles: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountUnreadMatching returns the number of unread articles of the user
+// matching each filter, in the same order. All filters are counted in a
+// single pass over the user's unread articles.
+func (r *ArticleRepository) CountUnreadMatching(userID uuid.UUID, filters []domain.ArticleFilter) ([]int, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+
+	ctx := context.Background()
+
+	// The user's languages are looked up once for all the full-text filters
+	var languages []string
+	args := []interface{}{userID}
+	columns := make([]string, len(filters))
+	for i, filter := range filters {
+		if filter.Query != "" && len(filter.Languages) == 0 {
+			if languages == nil {
+				scoped, err := r.withSearchLanguages(filter, userID)
+				if err != nil {
+					return nil, err
+				}
+				languages = append([]string{}, scoped.Languages...)
+			}
+			filter.Languages = languages
+		}
+		filter.UserID = uuid.Nil
+		filter.IsRead = nil
+		var where string
+		where, args = articleFilterClause(filter, args)
+		columns[i] = "COUNT(*) FILTER (WHERE " + where + ")"
+	}
+
+	query := `
+		SELECT ` + strings.Join(columns, ", ") + `
+		FROM articles a
+		JOIN feeds f ON f.id = a.feed_id
+		WHERE f.user_id = $1 AND a.is_read = false
+	`
+
+	counts := make([]int, len(filters))
+	dest := make([]interface{}, len(filters))
+	for i := range counts {
+		dest[i] = &counts[i]
+	}
+
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(dest...); err != nil {
+		return nil, fmt.Errorf("counting unread matching articles: %w", err)
+	}
+
+	return counts, nil
+}
+
+// RecentlyRead returns the user's articles ordered by when they were last
+// read or opened, most recent first.
+func (r *ArticleRepository) RecentlyRead(userID uuid.UUID, limit, offset int) ([]*domain.Article, error) {
+	ctx := context.Background()
+
+	query := `
 		SELECT a.id, a.feed_id, a.guid, a.title, a.url, a.content, a.summary, a.ai_summary, a.author,
 		       a.image_url, a.published_at, a.is_read, a.is_favorite, a.read_at, a.created_at,
-		       f.title as feed_title,
-		       ts_rank_cd(a.tsv, websearch_to_tsquery('french', $2)) as rank
-		FROM articles a
+		       a.sanitizer_version, a.favorited_at, a.language, ` + articleTagsColumn + `,
+		       EXISTS (SELECT 1 FROM read_later rl WHERE rl.article_id = a.id) AS read_later, f.title as feed_title,
+		       0::real as rank
+		FROM (
+			SELECT article_id, MAX(created_at) AS last_read
+			FROM reading_events
+			WHERE user_id = $1 AND article_id IS NOT NULL
+			GROUP BY article_id
+		) e
+		JOIN articles a ON a.id = e.article_id
 		JOIN feeds f ON f.id = a.feed_id
-		WHERE f.user_id = $1 AND a.tsv @@ websearch_to_tsquery('french', $2)
-		ORDER BY rank DESC, a.published_at DESC
-		LIMIT $3 OFFSET $4
+		WHERE f.user_id = $1
+		ORDER BY e.last_read DESC, a.id
+		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.pool.Query(ctx, sql, userID, query, limit, offset)
+	rows, err := r.pool.Query(ctx, query, userID, limit, offset)
 	if err != nil {
-		return nil, fmt.Errorf("searching articles: %w", err)
+		return nil, fmt.Errorf("getting recently read articles: %w", err)
 	}
 	defer rows.Close()
 
 	return r.scanArticlesWithRank(rows)
 }
 
+// articleFilterClause builds the WHERE conditions for a filter, appending
+// their parameters to args. The full-text query, when set, is always the
+// first parameter added so that the rank expression can refer to it.
+func articleFilterClause(filter domain.ArticleFilter, args []interface{}) (string, []interface{}) {
+	var conds []string
+	add := func(cond string, value interface{}) {
+		args = append(args, value)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if filter.Query != "" {
+		// The expanded query over the user's languages can use the GIN index;
+		// the article's own configuration then decides the match
+		args = append(args, filter.Query)
+		n := len(args)
+		configs := utils.TextSearchConfigs(filter.Languages)
+		expanded := make([]string, len(configs))
+		for i, cfg := range configs {
+			expanded[i] = fmt.Sprintf("websearch_to_tsquery('%s', $%d)", cfg, n)
+		}
+		match := "a.tsv @@ (" + strings.Join(expanded, " || ") + ")" +
+			fmt.Sprintf(" AND a.tsv @@ websearch_to_tsquery(article_ts_config(a.language), $%d)", n)
+		if filter.Fuzzy {
+			// word_similarity (<%) finds typos and prefixes in article
+			// titles, backed by a trigram index (migration 021). Feed titles
+			// are matched by the quick switcher, not here.
+			match = fmt.Sprintf("((%s) OR $%d <%% a.title)", match, n)
+		}
+		conds = append(conds, match)
+	}
+	if filter.UserID != uuid.Nil {
+		add("f.user_id = $%d", filter.UserID)
+	}
+	if len(filter.IDs) > 0 {
+		add("a.id = ANY($%d)", filter.IDs)
+	}
+	if len(filter.FeedIDs) > 0 {
+		add("a.feed_id = ANY($%d)", filter.FeedIDs)
+	}
+	if filter.Category != "" {
+		add("f.category = $%d", filter.Category)
+	}
+	if filter.IsRead != nil {
+		add("a.is_read = $%d", *filter.IsRead)
+	}
+	if filter.IsFavorite != nil {
+		add("a.is_favorite = $%d", *filter.IsFavorite)
+	}
+	if filter.Author != "" {
+		add("a.author ILIKE $%d", likePatterns([]string{filter.Author})[0])
+	}
+	if filter.PublishedAfter != nil {
+		add("a.published_at >= $%d", *filter.PublishedAfter)
+	}
+	if filter.PublishedBefore != nil {
+		add("a.published_at < $%d", *filter.PublishedBefore)
+	}
+	for _, tag := range filter.Tags {
+		add("EXISTS (SELECT 1 FROM article_tags at JOIN tags t ON t.id = at.tag_id WHERE at.article_id = a.id AND LOWER(t.name) = LOWER($%d))", tag)
+	}
+	if len(filter.FeedTitles) > 0 {
+		add("f.title ILIKE ANY($%d)", likePatterns(filter.FeedTitles))
+	}
+	if len(filter.ExcludeFeedTitles) > 0 {
+		add("NOT (f.title ILIKE ANY($%d))", likePatterns(filter.ExcludeFeedTitles))
+	}
+	if len(filter.Authors) > 0 {
+		add("a.author ILIKE ANY($%d)", likePatterns(filter.Authors))
+	}
+	if len(filter.ExcludeAuthors) > 0 {
+		add("NOT COALESCE(a.author ILIKE ANY($%d), false)", likePatterns(filter.ExcludeAuthors))
+	}
+	if len(filter.ExcludeTags) > 0 {
+		add("NOT EXISTS (SELECT 1 FROM article_tags at JOIN tags t ON t.id = at.tag_id WHERE at.article_id = a.id AND LOWER(t.name) = ANY($%d))", lowerAll(filter.ExcludeTags))
+	}
+	if filter.CreatedBefore != nil {
+		add("a.created_at <= $%d", *filter.CreatedBefore)
+	}
+	if filter.HasImage != nil {
+		if *filter.HasImage {
+			conds = append(conds, "COALESCE(a.image_url, '') <> ''")
+		} else {
+			conds = append(conds, "COALESCE(a.image_url, '') = ''")
+		}
+	}
+
+	if len(conds) == 0 {
+		return "TRUE", args
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+// likePatterns turns values into ILIKE substring patterns, escaping the
+// LIKE wildcards they contain.
+func likePatterns(values []string) []string {
+	escaper := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	patterns := make([]string, len(values))
+	for i, v := range values {
+		patterns[i] = "%" + escaper.Replace(v) + "%"
+	}
+	return patterns
+}
+
+// lowerAll returns the values in lower case.
+func lowerAll(values []string) []string {
+	lowered := make([]string, len(values))
+	for i, v := range values {
+		lowered[i] = strings.ToLower(v)
+	}
+	return lowered
+}
+
+// articleTagsColumn selects the names of an article's tags.
+const articleTagsColumn = `ARRAY(SELECT t.name FROM article_tags at JOIN tags t ON t.id = at.tag_id WHERE at.article_id = a.id ORDER BY LOWER(t.name)) AS tags`
+
+// articleOrder returns the stable ordering of an article list: newest or
+// oldest first with undated articles last, ties broken by insertion time and
+// ID so that keyset pagination never skips or repeats rows. Relevance orders
+// by rank first, then newest.
+func articleOrder(sort domain.ArticleSort, rankExpr string) string {
+	switch sort {
+	case domain.SortOldest:
+		return `COALESCE(a.published_at, 'infinity'::timestamptz) ASC, a.created_at ASC, a.id ASC`
+	case domain.SortRelevance:
+		return rankExpr + ` DESC, COALESCE(a.published_at, '-infinity'::timestamptz) DESC, a.created_at DESC, a.id DESC`
+	default:
+		return `COALESCE(a.published_at, '-infinity'::timestamptz) DESC, a.created_at DESC, a.id DESC`
+	}
+}
+
+// pageClauses builds the keyset condition and the LIMIT/OFFSET clause for a
+// page, appending their parameters to args. The keyset tuple mirrors
+// articleOrder.
+func pageClauses(page domain.Page, sort domain.ArticleSort, rankExpr string, args []interface{}) (string, string, []interface{}) {
+	keyset := ""
+	offset := page.Offset
+	if c := page.After; c != nil {
+		n := len(args)
+		switch {
+		case sort == domain.SortOldest:
+			keyset = fmt.Sprintf(
+				" AND (COALESCE(a.published_at, 'infinity'::timestamptz), a.created_at, a.id) > (COALESCE($%d::timestamptz, 'infinity'::timestamptz), $%d, $%d)",
+				n+1, n+2, n+3)
+		case sort == domain.SortRelevance && c.Rank != nil:
+			keyset = fmt.Sprintf(
+				" AND (%s, COALESCE(a.published_at, '-infinity'::timestamptz), a.created_at, a.id) < ($%d::real, COALESCE($%d::timestamptz, '-infinity'::timestamptz), $%d, $%d)",
+				rankExpr, n+1, n+2, n+3, n+4)
+			args = append(args, *c.Rank)
+		default:
+			keyset = fmt.Sprintf(
+				" AND (COALESCE(a.published_at, '-infinity'::timestamptz), a.created_at, a.id) < (COALESCE($%d::timestamptz, '-infinity'::timestamptz), $%d, $%d)",
+				n+1, n+2, n+3)
+		}
+		args = append(args, c.PublishedAt, c.CreatedAt, c.ID)
+		offset = 0
+	}
+
+	args = append(args, page.Limit, offset)
+	pagination := fmt.Sprintf(`
+		LIMIT $%d OFFSET $%d`, len(args)-1, len(args))
+
+	return keyset, pagination, args
+}
+
 // scanArticlesWithRank scans multiple article rows with their rank.
 func (r *ArticleRepository) scanArticlesWithRank(rows pgx.Rows) ([]*domain.Article, error) {
 	var articles []*domain.Article
 	for rows.Next() {
 		var article domain.Article
-		var url, content, summary, aiSummary, author, imageURL, feedTitle *string
+		var url, content, summary, aiSummary, author, imageURL, language, feedTitle *string
 		var publishedAt, readAt *time.Time
-		var rank float32
 
 		err := rows.Scan(
 			&article.ID,
@@ -472,8 +1056,13 @@ func (r *ArticleRepository) scanArticlesWithRank(rows pgx.Rows) ([]*domain.Artic
 			&article.IsFavorite,
 			&readAt,
 			&article.CreatedAt,
+			&article.SanitizerVersion,
+			&article.FavoritedAt,
+			&language,
+			&article.Tags,
+			&article.ReadLater,
 			&feedTitle,
-			&rank,
+			&article.Rank,
 		)
 
 		if err != nil {
@@ -498,6 +1087,9 @@ func (r *ArticleRepository) scanArticlesWithRank(rows pgx.Rows) ([]*domain.Artic
 		if imageURL != nil {
 			article.ImageURL = *imageURL
 		}
+		if language != nil {
+			article.Language = *language
+		}
 		if feedTitle != nil {
 			article.FeedTitle = *feedTitle
 		}
@@ -521,21 +1113,208 @@ func (r *ArticleRepository) UpdateAISummary(id uuid.UUID, summary string) error
 	return nil
 }
 
-// DeleteOldArticles removes articles older than the specified duration, except for favorites.
-func (r *ArticleRepository) DeleteOldArticles(ctx context.Context, olderThan time.Duration) (int64, error) {
-	threshold := time.Now().Add(-olderThan)
+// GetFullText retrieves the cached full-page content of an article.
+// It returns nil when nothing has been extracted yet.
+func (r *ArticleRepository) GetFullText(id uuid.UUID) (*domain.ArticleFullText, error) {
+	ctx := context.Background()
+
+	query := `
+		SELECT id, full_content, full_content_title, full_content_byline,
+		       full_content_site_name, full_content_lead_image, full_content_fetched_at
+		FROM articles
+		WHERE id = $1 AND full_content_fetched_at IS NOT NULL
+	`
+
+	var fullText domain.ArticleFullText
+	var content, title, byline, siteName, leadImage *string
+
+	err := r.pool.QueryRow(ctx, query, id).Scan(
+		&fullText.ArticleID,
+		&content,
+		&title,
+		&byline,
+		&siteName,
+		&leadImage,
+		&fullText.FetchedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting full text: %w", err)
+	}
+
+	if content != nil {
+		fullText.Content = *content
+	}
+	if title != nil {
+		fullText.Title = *title
+	}
+	if byline != nil {
+		fullText.Byline = *byline
+	}
+	if siteName != nil {
+		fullText.SiteName = *siteName
+	}
+	if leadImage != nil {
+		fullText.LeadImage = *leadImage
+	}
+
+	return &fullText, nil
+}
+
+// UpdateFullText stores the extracted full-page content and metadata of an
+// article.
+func (r *ArticleRepository) UpdateFullText(fullText *domain.ArticleFullText) error {
+	ctx := context.Background()
+
+	fullText.FetchedAt = time.Now()
 
 	query := `
-		DELETE FROM articles 
-		WHERE created_at < $1 AND is_favorite = false
+		UPDATE articles
+		SET full_content = $2, full_content_title = $3, full_content_byline = $4,
+		    full_content_site_name = $5, full_content_lead_image = $6, full_content_fetched_at = $7,
+		    full_content_raw = $8
+		WHERE id = $1
 	`
+	_, err := r.pool.Exec(ctx, query,
+		fullText.ArticleID,
+		nullString(fullText.Content),
+		nullString(fullText.Title),
+		nullString(fullText.Byline),
+		nullString(fullText.SiteName),
+		nullString(fullText.LeadImage),
+		fullText.FetchedAt,
+		nullString(fullText.RawContent),
+	)
+	if err != nil {
+		return fmt.Errorf("updating full text: %w", err)
+	}
+
+	return nil
+}
 
-	result, err := r.pool.Exec(ctx, query, threshold)
+// GetStaleSanitized returns up to limit articles whose stored HTML was
+// sanitized with another policy than version, with the HTML as received when
+// it was kept.
+func (r *ArticleRepository) GetStaleSanitized(ctx context.Context, version, limit int) ([]*domain.ArticleHTML, error) {
+	query := `
+		SELECT id, COALESCE(content_raw, content), COALESCE(summary_raw, summary),
+		       COALESCE(full_content_raw, full_content)
+		FROM articles
+		WHERE sanitizer_version <> $1
+		LIMIT $2
+	`
+
+	rows, err := r.pool.Query(ctx, query, version, limit)
 	if err != nil {
-		return 0, fmt.Errorf("deleting old articles: %w", err)
+		return nil, fmt.Errorf("querying stale articles: %w", err)
 	}
+	defer rows.Close()
 
-	return result.RowsAffected(), nil
+	var items []*domain.ArticleHTML
+	for rows.Next() {
+		var item domain.ArticleHTML
+		var content, summary, fullContent *string
+		if err := rows.Scan(&item.ID, &content, &summary, &fullContent); err != nil {
+			return nil, fmt.Errorf("scanning stale article: %w", err)
+		}
+		if content != nil {
+			item.Content = *content
+		}
+		if summary != nil {
+			item.Summary = *summary
+		}
+		if fullContent != nil {
+			item.FullContent = *fullContent
+		}
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
+
+// UpdateSanitized stores re-sanitized HTML and the policy version used.
+func (r *ArticleRepository) UpdateSanitized(ctx context.Context, items []*domain.ArticleHTML, version int) error {
+	batch := &pgx.Batch{}
+	query := `
+		UPDATE articles
+		SET content = $2, summary = $3, full_content = $4, sanitizer_version = $5
+		WHERE id = $1
+	`
+
+	for _, item := range items {
+		batch.Queue(query, item.ID, nullString(item.Content), nullString(item.Summary), nullString(item.FullContent), version)
+	}
+
+	results := r.pool.SendBatch(ctx, batch)
+	defer results.Close()
+
+	for range items {
+		if _, err := results.Exec(); err != nil {
+			return fmt.Errorf("updating sanitized article: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetUndetectedLanguage returns up to limit articles whose language has not
+// been detected yet.
+func (r *ArticleRepository) GetUndetectedLanguage(ctx context.Context, limit int) ([]*domain.ArticleText, error) {
+	query := `
+		SELECT id, feed_id, title, summary, content
+		FROM articles
+		WHERE language IS NULL
+		ORDER BY created_at DESC
+		LIMIT $1
+	`
+
+	rows, err := r.pool.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("querying articles without language: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*domain.ArticleText
+	for rows.Next() {
+		var item domain.ArticleText
+		var summary, content *string
+		if err := rows.Scan(&item.ID, &item.FeedID, &item.Title, &summary, &content); err != nil {
+			return nil, fmt.Errorf("scanning article text: %w", err)
+		}
+		if summary != nil {
+			item.Summary = *summary
+		}
+		if content != nil {
+			item.Content = *content
+		}
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
+
+// UpdateLanguages stores detected article languages. The search vector is
+// regenerated with the matching configuration.
+func (r *ArticleRepository) UpdateLanguages(ctx context.Context, items []*domain.ArticleText) error {
+	batch := &pgx.Batch{}
+	query := `UPDATE articles SET language = $2 WHERE id = $1`
+
+	for _, item := range items {
+		batch.Queue(query, item.ID, item.Language)
+	}
+
+	results := r.pool.SendBatch(ctx, batch)
+	defer results.Close()
+
+	for range items {
+		if _, err := results.Exec(); err != nil {
+			return fmt.Errorf("updating article language: %w", err)
+		}
+	}
+
+	return nil
 }
 
 // nullString returns nil if string is empty.
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/michael/flowreader/internal/domain"
+)
+
+// DailyEditionRepository implements domain.DailyEditionRepository using
+// PostgreSQL. The latest EPUB file of each user is stored in the row.
+type DailyEditionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewDailyEditionRepository creates a new daily edition repository.
+func NewDailyEditionRepository(pool *pgxpool.Pool) *DailyEditionRepository {
+	return &DailyEditionRepository{pool: pool}
+}
+
+// dailyEditionColumns are the settings and status columns, without the file.
+const dailyEditionColumns = `
+	user_id, enabled, source, category, saved_search_id, max_articles, hour, timezone,
+	article_count, size_bytes, generated_at, attempted_at, last_error, created_at, updated_at
+`
+
+func scanDailyEdition(row pgx.Row) (*domain.DailyEdition, error) {
+	var edition domain.DailyEdition
+	var source string
+	var category, lastError *string
+
+	err := row.Scan(
+		&edition.UserID,
+		&edition.Enabled,
+		&source,
+		&category,
+		&edition.SavedSearchID,
+		&edition.MaxArticles,
+		&edition.Hour,
+		&edition.Timezone,
+		&edition.ArticleCount,
+		&edition.SizeBytes,
+		&edition.GeneratedAt,
+		&edition.AttemptedAt,
+		&lastError,
+		&edition.CreatedAt,
+		&edition.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	edition.Source = domain.EditionSource(source)
+	if category != nil {
+		edition.Category = *category
+	}
+	if lastError != nil {
+		edition.LastError = *lastError
+	}
+
+	return &edition, nil
+}
+
+// Get retrieves the daily edition settings of a user.
+func (r *DailyEditionRepository) Get(userID uuid.UUID) (*domain.DailyEdition, error) {
+	ctx := context.Background()
+
+	query := `SELECT ` + dailyEditionColumns + ` FROM daily_editions WHERE user_id = $1`
+
+	edition, err := scanDailyEdition(r.pool.QueryRow(ctx, query, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting daily edition: %w", err)
+	}
+
+	return edition, nil
+}
+
+// Save creates or updates the daily edition settings of a user. Changing the
+// settings allows a new edition to be generated the same day.
+func (r *DailyEditionRepository) Save(edition *domain.DailyEdition) error {
+	ctx := context.Background()
+
+	query := `
+		INSERT INTO daily_editions (user_id, enabled, source, category, saved_search_id,
+		                            max_articles, hour, timezone)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (user_id) DO UPDATE
+		SET enabled = EXCLUDED.enabled, source = EXCLUDED.source, category = EXCLUDED.category,
+		    saved_search_id = EXCLUDED.saved_search_id, max_articles = EXCLUDED.max_articles,
+		    hour = EXCLUDED.hour, timezone = EXCLUDED.timezone,
+		    attempted_at = NULL, last_error = NULL, updated_at = NOW()
+		RETURNING created_at, updated_at
+	`
+
+	err := r.pool.QueryRow(ctx, query,
+		edition.UserID,
+		edition.Enabled,
+		string(edition.Source),
+		nullString(edition.Category),
+		edition.SavedSearchID,
+		edition.MaxArticles,
+		edition.Hour,
+		edition.Timezone,
+	).Scan(&edition.CreatedAt, &edition.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("saving daily edition: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the daily edition settings and file of a user.
+func (r *DailyEditionRepository) Delete(userID uuid.UUID) (bool, error) {
+	ctx := context.Background()
+
+	tag, err := r.pool.Exec(ctx, `DELETE FROM daily_editions WHERE user_id = $1`, userID)
+	if err != nil {
+		return false, fmt.Errorf("deleting daily edition: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// ListEnabled returns the settings of every enabled daily edition.
+func (r *DailyEditionRepository) ListEnabled() ([]*domain.DailyEdition, error) {
+	ctx := context.Background()
+
+	query := `SELECT ` + dailyEditionColumns + ` FROM daily_editions WHERE enabled ORDER BY user_id`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("querying daily editions: %w", err)
+	}
+	defer rows.Close()
+
+	var editions []*domain.DailyEdition
+	for rows.Next() {
+		edition, err := scanDailyEdition(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning daily edition: %w", err)
+		}
+		editions = append(editions, edition)
+	}
+
+	return editions, rows.Err()
+}
+
+// StoreFile fills the download slot of a user with a generated edition. An
+// empty edition clears the slot.
+func (r *DailyEditionRepository) StoreFile(userID uuid.UUID, data []byte, articleCount int, generatedAt time.Time) error {
+	ctx := context.Background()
+
+	query := `
+		UPDATE daily_editions
+		SET epub = $2, article_count = $3, size_bytes = $4, generated_at = $5,
+		    attempted_at = $5, last_error = NULL
+		WHERE user_id = $1
+	`
+
+	if len(data) == 0 {
+		data = nil
+	}
+	_, err := r.pool.Exec(ctx, query, userID, data, articleCount, len(data), generatedAt)
+	if err != nil {
+		return fmt.Errorf("storing daily edition: %w", err)
+	}
+
+	return nil
+}
+
+// StoreFailure records a failed generation, keeping the previous file.
+func (r *DailyEditionRepository) StoreFailure(userID uuid.UUID, message string, attemptedAt time.Time) error {
+	ctx := context.Background()
+
+	query := `UPDATE daily_editions SET attempted_at = $2, last_error = $3 WHERE user_id = $1`
+
+	_, err := r.pool.Exec(ctx, query, userID, attemptedAt, message)
+	if err != nil {
+		return fmt.Errorf("storing daily edition failure: %w", err)
+	}
+
+	return nil
+}
+
+// GetFile returns the latest edition file of a user, nil when the slot is
+// empty.
+func (r *DailyEditionRepository) GetFile(userID uuid.UUID) ([]byte, error) {
+	ctx := context.Background()
+
+	var data []byte
+	err := r.pool.QueryRow(ctx, `SELECT epub FROM daily_editions WHERE user_id = $1`, userID).Scan(&data)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting daily edition file: %w", err)
+	}
+
+	return data, nil
+}
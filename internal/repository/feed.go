@@ -27,8 +27,8 @@ func (r *FeedRepository) Create(feed *domain.Feed) error {
 	ctx := context.Background()
 
 	query := `
-		INSERT INTO feeds (id, user_id, url, title, description, site_url, image_url, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO feeds (id, user_id, url, title, description, site_url, image_url, created_at, updated_at, category)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	`
 
 	_, err := r.pool.Exec(ctx, query,
@@ -41,6 +41,7 @@ func (r *FeedRepository) Create(feed *domain.Feed) error {
 		feed.ImageURL,
 		feed.CreatedAt,
 		feed.UpdatedAt,
+		nullString(feed.Category),
 	)
 
 	if err != nil {
@@ -56,13 +57,13 @@ func (r *FeedRepository) GetByID(id uuid.UUID) (*domain.Feed, error) {
 
 	query := `
 		SELECT id, user_id, url, title, description, site_url, image_url, 
-		       last_fetched_at, fetch_error, created_at, updated_at
+		       last_fetched_at, fetch_error, created_at, updated_at, category
 		FROM feeds
 		WHERE id = $1
 	`
 
 	var feed domain.Feed
-	var description, siteURL, imageURL, fetchError *string
+	var description, siteURL, imageURL, fetchError, category *string
 	var lastFetchedAt *time.Time
 
 	err := r.pool.QueryRow(ctx, query, id).Scan(
@@ -77,6 +78,7 @@ func (r *FeedRepository) GetByID(id uuid.UUID) (*domain.Feed, error) {
 		&fetchError,
 		&feed.CreatedAt,
 		&feed.UpdatedAt,
+		&category,
 	)
 
 	if err != nil {
@@ -98,6 +100,9 @@ func (r *FeedRepository) GetByID(id uuid.UUID) (*domain.Feed, error) {
 	if fetchError != nil {
 		feed.FetchError = *fetchError
 	}
+	if category != nil {
+		feed.Category = *category
+	}
 	feed.LastFetchedAt = lastFetchedAt
 
 	return &feed, nil
@@ -109,7 +114,7 @@ func (r *FeedRepository) GetByUserID(userID uuid.UUID) ([]*domain.Feed, error) {
 
 	query := `
 		SELECT f.id, f.user_id, f.url, f.title, f.description, f.site_url, f.image_url,
-		       f.last_fetched_at, f.fetch_error, f.created_at, f.updated_at,
+		       f.last_fetched_at, f.fetch_error, f.created_at, f.updated_at, f.category,
 		       COALESCE((SELECT COUNT(*) FROM articles a WHERE a.feed_id = f.id AND a.is_read = false), 0) as unread_count
 		FROM feeds f
 		WHERE f.user_id = $1
@@ -125,7 +130,7 @@ func (r *FeedRepository) GetByUserID(userID uuid.UUID) ([]*domain.Feed, error) {
 	var feeds []*domain.Feed
 	for rows.Next() {
 		var feed domain.Feed
-		var description, siteURL, imageURL, fetchError *string
+		var description, siteURL, imageURL, fetchError, category *string
 		var lastFetchedAt *time.Time
 
 		err := rows.Scan(
@@ -140,6 +145,7 @@ func (r *FeedRepository) GetByUserID(userID uuid.UUID) ([]*domain.Feed, error) {
 			&fetchError,
 			&feed.CreatedAt,
 			&feed.UpdatedAt,
+			&category,
 			&feed.UnreadCount,
 		)
 		if err != nil {
@@ -158,6 +164,9 @@ func (r *FeedRepository) GetByUserID(userID uuid.UUID) ([]*domain.Feed, error) {
 		if fetchError != nil {
 			feed.FetchError = *fetchError
 		}
+		if category != nil {
+			feed.Category = *category
+		}
 		feed.LastFetchedAt = lastFetchedAt
 
 		feeds = append(feeds, &feed)
@@ -172,13 +181,13 @@ func (r *FeedRepository) GetByURL(userID uuid.UUID, url string) (*domain.Feed, e
 
 	query := `
 		SELECT id, user_id, url, title, description, site_url, image_url,
-		       last_fetched_at, fetch_error, created_at, updated_at
+		       last_fetched_at, fetch_error, created_at, updated_at, category
 		FROM feeds
 		WHERE user_id = $1 AND url = $2
 	`
 
 	var feed domain.Feed
-	var description, siteURL, imageURL, fetchError *string
+	var description, siteURL, imageURL, fetchError, category *string
 	var lastFetchedAt *time.Time
 
 	err := r.pool.QueryRow(ctx, query, userID, url).Scan(
@@ -193,6 +202,7 @@ func (r *FeedRepository) GetByURL(userID uuid.UUID, url string) (*domain.Feed, e
 		&fetchError,
 		&feed.CreatedAt,
 		&feed.UpdatedAt,
+		&category,
 	)
 
 	if err != nil {
@@ -214,6 +224,9 @@ func (r *FeedRepository) GetByURL(userID uuid.UUID, url string) (*domain.Feed, e
 	if fetchError != nil {
 		feed.FetchError = *fetchError
 	}
+	if category != nil {
+		feed.Category = *category
+	}
 	feed.LastFetchedAt = lastFetchedAt
 
 	return &feed, nil
@@ -225,7 +238,7 @@ func (r *FeedRepository) Update(feed *domain.Feed) error {
 
 	query := `
 		UPDATE feeds
-		SET title = $2, description = $3, site_url = $4, image_url = $5, updated_at = $6
+		SET title = $2, description = $3, site_url = $4, image_url = $5, updated_at = $6, category = $7
 		WHERE id = $1
 	`
 
@@ -236,6 +249,7 @@ func (r *FeedRepository) Update(feed *domain.Feed) error {
 		feed.SiteURL,
 		feed.ImageURL,
 		time.Now(),
+		nullString(feed.Category),
 	)
 
 	if err != nil {
@@ -264,7 +278,7 @@ func (r *FeedRepository) GetFeedsToFetch(limit int) ([]*domain.Feed, error) {
 
 	query := `
 		SELECT id, user_id, url, title, description, site_url, image_url,
-		       last_fetched_at, fetch_error, created_at, updated_at
+		       last_fetched_at, fetch_error, created_at, updated_at, category
 		FROM feeds
 		WHERE last_fetched_at IS NULL 
 		   OR last_fetched_at < NOW() - INTERVAL '15 minutes'
@@ -281,7 +295,7 @@ func (r *FeedRepository) GetFeedsToFetch(limit int) ([]*domain.Feed, error) {
 	var feeds []*domain.Feed
 	for rows.Next() {
 		var feed domain.Feed
-		var description, siteURL, imageURL, fetchError *string
+		var description, siteURL, imageURL, fetchError, category *string
 		var lastFetchedAt *time.Time
 
 		err := rows.Scan(
@@ -296,6 +310,7 @@ func (r *FeedRepository) GetFeedsToFetch(limit int) ([]*domain.Feed, error) {
 			&fetchError,
 			&feed.CreatedAt,
 			&feed.UpdatedAt,
+			&category,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scanning feed: %w", err)
@@ -313,6 +328,9 @@ func (r *FeedRepository) GetFeedsToFetch(limit int) ([]*domain.Feed, error) {
 		if fetchError != nil {
 			feed.FetchError = *fetchError
 		}
+		if category != nil {
+			feed.Category = *category
+		}
 		feed.LastFetchedAt = lastFetchedAt
 
 		feeds = append(feeds, &feed)
@@ -343,3 +361,25 @@ func (r *FeedRepository) UpdateFetchStatus(id uuid.UUID, fetchedAt time.Time, fe
 
 	return nil
 }
+
+// AddLanguages records languages seen in a feed's articles.
+func (r *FeedRepository) AddLanguages(id uuid.UUID, languages []string) error {
+	if len(languages) == 0 {
+		return nil
+	}
+
+	ctx := context.Background()
+
+	query := `
+		UPDATE feeds
+		SET languages = ARRAY(SELECT DISTINCT l FROM unnest(languages || $2::text[]) AS l ORDER BY l)
+		WHERE id = $1 AND NOT (languages @> $2::text[])
+	`
+
+	_, err := r.pool.Exec(ctx, query, id, languages)
+	if err != nil {
+		return fmt.Errorf("adding feed languages: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/michael/flowreader/internal/domain"
+)
+
+// HighlightRepository implements domain.HighlightRepository using PostgreSQL.
+type HighlightRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewHighlightRepository creates a new highlight repository.
+func NewHighlightRepository(pool *pgxpool.Pool) *HighlightRepository {
+	return &HighlightRepository{pool: pool}
+}
+
+// Create inserts a new highlight.
+func (r *HighlightRepository) Create(h *domain.Highlight) error {
+	ctx := context.Background()
+
+	query := `
+		INSERT INTO highlights (id, user_id, article_id, quote, prefix, suffix, start_offset, color, note, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		h.ID,
+		h.UserID,
+		h.ArticleID,
+		h.Quote,
+		nullString(h.Prefix),
+		nullString(h.Suffix),
+		h.StartOffset,
+		h.Color,
+		nullString(h.Note),
+		h.CreatedAt,
+		h.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("creating highlight: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a highlight by its ID.
+func (r *HighlightRepository) GetByID(id uuid.UUID) (*domain.Highlight, error) {
+	ctx := context.Background()
+
+	query := `
+		SELECT h.id, h.user_id, h.article_id, h.quote, h.prefix, h.suffix, h.start_offset,
+		       h.color, h.note, h.created_at, h.updated_at, a.title, a.url, f.title
+		FROM highlights h
+		JOIN articles a ON a.id = h.article_id
+		JOIN feeds f ON f.id = a.feed_id
+		WHERE h.id = $1
+	`
+
+	h, err := scanHighlight(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting highlight by ID: %w", err)
+	}
+
+	return h, nil
+}
+
+// GetByArticle retrieves a user's highlights on an article in reading order.
+func (r *HighlightRepository) GetByArticle(userID, articleID uuid.UUID) ([]*domain.Highlight, error) {
+	ctx := context.Background()
+
+	query := `
+		SELECT h.id, h.user_id, h.article_id, h.quote, h.prefix, h.suffix, h.start_offset,
+		       h.color, h.note, h.created_at, h.updated_at, a.title, a.url, f.title
+		FROM highlights h
+		JOIN articles a ON a.id = h.article_id
+		JOIN feeds f ON f.id = a.feed_id
+		WHERE h.user_id = $1 AND h.article_id = $2
+		ORDER BY h.start_offset ASC NULLS LAST, h.created_at ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("querying highlights: %w", err)
+	}
+	defer rows.Close()
+
+	return scanHighlights(rows)
+}
+
+// GetByArticles retrieves a user's highlights on several articles, grouped
+// by article in reading order.
+func (r *HighlightRepository) GetByArticles(userID uuid.UUID, articleIDs []uuid.UUID) (map[uuid.UUID][]*domain.Highlight, error) {
+	if len(articleIDs) == 0 {
+		return map[uuid.UUID][]*domain.Highlight{}, nil
+	}
+
+	ctx := context.Background()
+
+	query := `
+		SELECT h.id, h.user_id, h.article_id, h.quote, h.prefix, h.suffix, h.start_offset,
+		       h.color, h.note, h.created_at, h.updated_at, a.title, a.url, f.title
+		FROM highlights h
+		JOIN articles a ON a.id = h.article_id
+		JOIN feeds f ON f.id = a.feed_id
+		WHERE h.user_id = $1 AND h.article_id = ANY($2)
+		ORDER BY h.article_id, h.start_offset ASC NULLS LAST, h.created_at ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID, articleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("querying highlights: %w", err)
+	}
+	defer rows.Close()
+
+	highlights, err := scanHighlights(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	byArticle := make(map[uuid.UUID][]*domain.Highlight)
+	for _, h := range highlights {
+		byArticle[h.ArticleID] = append(byArticle[h.ArticleID], h)
+	}
+	return byArticle, nil
+}
+
+// GetByUserID retrieves a user's highlights across all articles, newest first.
+func (r *HighlightRepository) GetByUserID(userID uuid.UUID, limit, offset int) ([]*domain.Highlight, error) {
+	ctx := context.Background()
+
+	query := `
+		SELECT h.id, h.user_id, h.article_id, h.quote, h.prefix, h.suffix, h.start_offset,
+		       h.color, h.note, h.created_at, h.updated_at, a.title, a.url, f.title
+		FROM highlights h
+		JOIN articles a ON a.id = h.article_id
+		JOIN feeds f ON f.id = a.feed_id
+		WHERE h.user_id = $1
+		ORDER BY h.created_at DESC, h.id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("querying highlights: %w", err)
+	}
+	defer rows.Close()
+
+	return scanHighlights(rows)
+}
+
+// Update stores a highlight's color and note.
+func (r *HighlightRepository) Update(h *domain.Highlight) error {
+	ctx := context.Background()
+
+	h.UpdatedAt = time.Now()
+	query := `UPDATE highlights SET color = $2, note = $3, updated_at = $4 WHERE id = $1`
+	_, err := r.pool.Exec(ctx, query, h.ID, h.Color, nullString(h.Note), h.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("updating highlight: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a highlight.
+func (r *HighlightRepository) Delete(id uuid.UUID) error {
+	ctx := context.Background()
+	_, err := r.pool.Exec(ctx, "DELETE FROM highlights WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("deleting highlight: %w", err)
+	}
+	return nil
+}
+
+// scanHighlight scans a single highlight row.
+func scanHighlight(row pgx.Row) (*domain.Highlight, error) {
+	var h domain.Highlight
+	var prefix, suffix, note, articleURL *string
+
+	err := row.Scan(
+		&h.ID,
+		&h.UserID,
+		&h.ArticleID,
+		&h.Quote,
+		&prefix,
+		&suffix,
+		&h.StartOffset,
+		&h.Color,
+		&note,
+		&h.CreatedAt,
+		&h.UpdatedAt,
+		&h.ArticleTitle,
+		&articleURL,
+		&h.FeedTitle,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if prefix != nil {
+		h.Prefix = *prefix
+	}
+	if suffix != nil {
+		h.Suffix = *suffix
+	}
+	if note != nil {
+		h.Note = *note
+	}
+	if articleURL != nil {
+		h.ArticleURL = *articleURL
+	}
+
+	return &h, nil
+}
+
+// scanHighlights scans multiple highlight rows.
+func scanHighlights(rows pgx.Rows) ([]*domain.Highlight, error) {
+	var highlights []*domain.Highlight
+	for rows.Next() {
+		h, err := scanHighlight(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning highlight: %w", err)
+		}
+		highlights = append(highlights, h)
+	}
+	return highlights, nil
+}
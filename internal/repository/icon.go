@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/michael/flowreader/internal/domain"
+)
+
+// FeedIconRepository implements domain.FeedIconRepository using PostgreSQL.
+type FeedIconRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewFeedIconRepository creates a new feed icon repository.
+func NewFeedIconRepository(pool *pgxpool.Pool) *FeedIconRepository {
+	return &FeedIconRepository{pool: pool}
+}
+
+// Get retrieves the stored icon for a feed.
+func (r *FeedIconRepository) Get(feedID uuid.UUID) (*domain.FeedIcon, error) {
+	ctx := context.Background()
+
+	query := `
+		SELECT feed_id, data, mime_type, source_url, hash, fetched_at
+		FROM feed_icons
+		WHERE feed_id = $1
+	`
+
+	var icon domain.FeedIcon
+	var mimeType, sourceURL, hash *string
+
+	err := r.pool.QueryRow(ctx, query, feedID).Scan(
+		&icon.FeedID,
+		&icon.Data,
+		&mimeType,
+		&sourceURL,
+		&hash,
+		&icon.FetchedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting feed icon: %w", err)
+	}
+
+	if mimeType != nil {
+		icon.MimeType = *mimeType
+	}
+	if sourceURL != nil {
+		icon.SourceURL = *sourceURL
+	}
+	if hash != nil {
+		icon.Hash = *hash
+	}
+
+	return &icon, nil
+}
+
+// Upsert inserts or replaces the icon for a feed.
+func (r *FeedIconRepository) Upsert(icon *domain.FeedIcon) error {
+	ctx := context.Background()
+
+	query := `
+		INSERT INTO feed_icons (feed_id, data, mime_type, source_url, hash, fetched_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (feed_id) DO UPDATE
+		SET data = EXCLUDED.data,
+		    mime_type = EXCLUDED.mime_type,
+		    source_url = EXCLUDED.source_url,
+		    hash = EXCLUDED.hash,
+		    fetched_at = EXCLUDED.fetched_at
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		icon.FeedID,
+		icon.Data,
+		nullString(icon.MimeType),
+		nullString(icon.SourceURL),
+		nullString(icon.Hash),
+		icon.FetchedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("upserting feed icon: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/michael/flowreader/internal/domain"
+)
+
+// QuickFindRepository implements domain.QuickFindRepository using PostgreSQL.
+//
+// Each lookup matches substrings (ILIKE) and fuzzy words (pg_trgm's <%
+// operator, backed by the trigram indexes of migration 021). The score is the
+// trigram word similarity, boosted for substring matches so that exact hits
+// come first.
+type QuickFindRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewQuickFindRepository creates a new quick switcher repository.
+func NewQuickFindRepository(pool *pgxpool.Pool) *QuickFindRepository {
+	return &QuickFindRepository{pool: pool}
+}
+
+// FindFeeds returns the user's feeds whose title or URL matches the query.
+func (r *QuickFindRepository) FindFeeds(userID uuid.UUID, query string, limit int) ([]*domain.QuickFindFeed, error) {
+	ctx := context.Background()
+
+	sql := `
+		SELECT f.id, f.title, f.url, f.site_url, f.category,
+		       COALESCE((SELECT COUNT(*) FROM articles a WHERE a.feed_id = f.id AND a.is_read = false), 0) AS unread_count,
+		       (GREATEST(word_similarity($3, f.title), word_similarity($3, f.url))
+		        + CASE WHEN f.title ILIKE $2 OR f.url ILIKE $2 THEN 0.5 ELSE 0 END)::real AS score
+		FROM feeds f
+		WHERE f.user_id = $1
+		  AND (f.title ILIKE $2 OR f.url ILIKE $2 OR $3 <% f.title OR $3 <% f.url)
+		ORDER BY score DESC, LOWER(f.title) ASC
+		LIMIT $4
+	`
+
+	rows, err := r.pool.Query(ctx, sql, userID, likePatterns([]string{query})[0], query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("finding feeds: %w", err)
+	}
+	defer rows.Close()
+
+	var feeds []*domain.QuickFindFeed
+	for rows.Next() {
+		var feed domain.QuickFindFeed
+		var siteURL, category *string
+		if err := rows.Scan(
+			&feed.ID,
+			&feed.Title,
+			&feed.URL,
+			&siteURL,
+			&category,
+			&feed.UnreadCount,
+			&feed.Score,
+		); err != nil {
+			return nil, fmt.Errorf("scanning feed: %w", err)
+		}
+		if siteURL != nil {
+			feed.SiteURL = *siteURL
+		}
+		if category != nil {
+			feed.Category = *category
+		}
+		feeds = append(feeds, &feed)
+	}
+
+	return feeds, rows.Err()
+}
+
+// FindCategories returns the user's feed categories matching the query.
+func (r *QuickFindRepository) FindCategories(userID uuid.UUID, query string, limit int) ([]*domain.QuickFindCategory, error) {
+	ctx := context.Background()
+
+	sql := `
+		SELECT f.category, COUNT(*) AS feed_count,
+		       COALESCE(SUM((SELECT COUNT(*) FROM articles a WHERE a.feed_id = f.id AND a.is_read = false)), 0) AS unread_count,
+		       (word_similarity($3, f.category)
+		        + CASE WHEN f.category ILIKE $2 THEN 0.5 ELSE 0 END)::real AS score
+		FROM feeds f
+		WHERE f.user_id = $1 AND COALESCE(f.category, '') <> ''
+		  AND (f.category ILIKE $2 OR $3 <% f.category)
+		GROUP BY f.category
+		ORDER BY score DESC, LOWER(f.category) ASC
+		LIMIT $4
+	`
+
+	rows, err := r.pool.Query(ctx, sql, userID, likePatterns([]string{query})[0], query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("finding categories: %w", err)
+	}
+	defer rows.Close()
+
+	var categories []*domain.QuickFindCategory
+	for rows.Next() {
+		var category domain.QuickFindCategory
+		if err := rows.Scan(&category.Name, &category.FeedCount, &category.UnreadCount, &category.Score); err != nil {
+			return nil, fmt.Errorf("scanning category: %w", err)
+		}
+		categories = append(categories, &category)
+	}
+
+	return categories, rows.Err()
+}
+
+// FindArticles returns the user's articles whose title matches the query,
+// best matches first and newest first among equals.
+func (r *QuickFindRepository) FindArticles(userID uuid.UUID, query string, limit int) ([]*domain.QuickFindArticle, error) {
+	ctx := context.Background()
+
+	sql := `
+		SELECT a.id, a.feed_id, f.title, a.title, a.published_at, a.is_read,
+		       (word_similarity($3, a.title)
+		        + CASE WHEN a.title ILIKE $2 THEN 0.5 ELSE 0 END)::real AS score
+		FROM articles a
+		JOIN feeds f ON f.id = a.feed_id
+		WHERE f.user_id = $1
+		  AND (a.title ILIKE $2 OR $3 <% a.title)
+		ORDER BY score DESC, COALESCE(a.published_at, '-infinity'::timestamptz) DESC, a.id DESC
+		LIMIT $4
+	`
+
+	rows, err := r.pool.Query(ctx, sql, userID, likePatterns([]string{query})[0], query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("finding articles: %w", err)
+	}
+	defer rows.Close()
+
+	var articles []*domain.QuickFindArticle
+	for rows.Next() {
+		var article domain.QuickFindArticle
+		if err := rows.Scan(
+			&article.ID,
+			&article.FeedID,
+			&article.FeedTitle,
+			&article.Title,
+			&article.PublishedAt,
+			&article.IsRead,
+			&article.Score,
+		); err != nil {
+			return nil, fmt.Errorf("scanning article: %w", err)
+		}
+		articles = append(articles, &article)
+	}
+
+	return articles, rows.Err()
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/michael/flowreader/internal/domain"
+)
+
+// ReadingRepository implements domain.ReadingRepository using PostgreSQL.
+type ReadingRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewReadingRepository creates a new reading history repository.
+func NewReadingRepository(pool *pgxpool.Pool) *ReadingRepository {
+	return &ReadingRepository{pool: pool}
+}
+
+// Record inserts a reading event.
+func (r *ReadingRepository) Record(event *domain.ReadingEvent) error {
+	ctx := context.Background()
+
+	query := `
+		INSERT INTO reading_events (id, user_id, article_id, feed_id, event_type, dwell_seconds, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		event.ID,
+		event.UserID,
+		event.ArticleID,
+		event.FeedID,
+		string(event.Type),
+		event.DwellSeconds,
+		event.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("recording reading event: %w", err)
+	}
+
+	return nil
+}
+
+// ReadsPerDay returns the number of read events per day since the given
+// time, oldest first. Days without reads are omitted.
+func (r *ReadingRepository) ReadsPerDay(userID uuid.UUID, since time.Time, tz string) ([]domain.DailyReadCount, error) {
+	ctx := context.Background()
+
+	query := `
+		SELECT to_char(created_at AT TIME ZONE $3, 'YYYY-MM-DD') AS day, COUNT(*)
+		FROM reading_events
+		WHERE user_id = $1 AND event_type = 'read' AND created_at >= $2
+		GROUP BY day
+		ORDER BY day
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID, since, tz)
+	if err != nil {
+		return nil, fmt.Errorf("counting reads per day: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []domain.DailyReadCount
+	for rows.Next() {
+		var c domain.DailyReadCount
+		if err := rows.Scan(&c.Date, &c.Count); err != nil {
+			return nil, fmt.Errorf("scanning daily read count: %w", err)
+		}
+		counts = append(counts, c)
+	}
+
+	return counts, rows.Err()
+}
+
+// ReadsPerFeed returns the number of read events per feed since the given
+// time, most read first. Reads from deleted feeds are not included.
+func (r *ReadingRepository) ReadsPerFeed(userID uuid.UUID, since time.Time) ([]domain.FeedReadCount, error) {
+	ctx := context.Background()
+
+	query := `
+		SELECT f.id, f.title, COUNT(*) AS reads
+		FROM reading_events e
+		JOIN feeds f ON f.id = e.feed_id
+		WHERE e.user_id = $1 AND e.event_type = 'read' AND e.created_at >= $2
+		GROUP BY f.id, f.title
+		ORDER BY reads DESC, f.title
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("counting reads per feed: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []domain.FeedReadCount
+	for rows.Next() {
+		var c domain.FeedReadCount
+		if err := rows.Scan(&c.FeedID, &c.FeedTitle, &c.Count); err != nil {
+			return nil, fmt.Errorf("scanning feed read count: %w", err)
+		}
+		counts = append(counts, c)
+	}
+
+	return counts, rows.Err()
+}
+
+// ReadsPerCategory returns the number of read events per feed category since
+// the given time, most read first.
+func (r *ReadingRepository) ReadsPerCategory(userID uuid.UUID, since time.Time) ([]domain.CategoryReadCount, error) {
+	ctx := context.Background()
+
+	query := `
+		SELECT COALESCE(f.category, '') AS category, COUNT(*) AS reads
+		FROM reading_events e
+		JOIN feeds f ON f.id = e.feed_id
+		WHERE e.user_id = $1 AND e.event_type = 'read' AND e.created_at >= $2
+		GROUP BY 1
+		ORDER BY reads DESC, category
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("counting reads per category: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []domain.CategoryReadCount
+	for rows.Next() {
+		var c domain.CategoryReadCount
+		if err := rows.Scan(&c.Category, &c.Count); err != nil {
+			return nil, fmt.Errorf("scanning category read count: %w", err)
+		}
+		counts = append(counts, c)
+	}
+
+	return counts, rows.Err()
+}
+
+// AverageDwell returns the average time spent on opened articles since the
+// given time, and the number of open events that reported it.
+func (r *ReadingRepository) AverageDwell(userID uuid.UUID, since time.Time) (float64, int, error) {
+	ctx := context.Background()
+
+	query := `
+		SELECT COALESCE(AVG(dwell_seconds), 0)::float8, COUNT(*)
+		FROM reading_events
+		WHERE user_id = $1 AND event_type = 'open' AND dwell_seconds IS NOT NULL AND created_at >= $2
+	`
+
+	var avg float64
+	var count int
+	if err := r.pool.QueryRow(ctx, query, userID, since).Scan(&avg, &count); err != nil {
+		return 0, 0, fmt.Errorf("averaging reading time: %w", err)
+	}
+
+	return avg, count, nil
+}
+
+// ReadDays returns every day the user read at least one article, oldest
+// first, as YYYY-MM-DD dates.
+func (r *ReadingRepository) ReadDays(userID uuid.UUID, tz string) ([]string, error) {
+	ctx := context.Background()
+
+	query := `
+		SELECT DISTINCT to_char(created_at AT TIME ZONE $2, 'YYYY-MM-DD') AS day
+		FROM reading_events
+		WHERE user_id = $1 AND event_type = 'read'
+		ORDER BY day
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID, tz)
+	if err != nil {
+		return nil, fmt.Errorf("listing reading days: %w", err)
+	}
+	defer rows.Close()
+
+	var days []string
+	for rows.Next() {
+		var day string
+		if err := rows.Scan(&day); err != nil {
+			return nil, fmt.Errorf("scanning reading day: %w", err)
+		}
+		days = append(days, day)
+	}
+
+	return days, rows.Err()
+}
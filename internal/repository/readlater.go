@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/michael/flowreader/internal/domain"
+)
+
+// ReadLaterRepository implements domain.ReadLaterRepository using PostgreSQL.
+type ReadLaterRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewReadLaterRepository creates a new read-later repository.
+func NewReadLaterRepository(pool *pgxpool.Pool) *ReadLaterRepository {
+	return &ReadLaterRepository{pool: pool}
+}
+
+// Add puts one of the user's articles in the queue (at the end, or at the
+// top when top is set) or updates its snooze. Snoozing marks the article as
+// read until the snooze expires. It returns nil if the article does not
+// exist or is not owned by the user.
+func (r *ReadLaterRepository) Add(userID, articleID uuid.UUID, top bool, snoozeUntil *time.Time) (*domain.ReadLaterItem, error) {
+	ctx := context.Background()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	position := `COALESCE((SELECT MAX(position) FROM read_later WHERE user_id = $1), 0) + 1`
+	if top {
+		position = `COALESCE((SELECT MIN(position) FROM read_later WHERE user_id = $1), 0) - 1`
+	}
+
+	query := `
+		INSERT INTO read_later (article_id, user_id, position, snoozed_until)
+		SELECT a.id, $1, ` + position + `, $3
+		FROM articles a
+		JOIN feeds f ON f.id = a.feed_id
+		WHERE a.id = $2 AND f.user_id = $1
+		ON CONFLICT (article_id) DO UPDATE
+		SET snoozed_until = EXCLUDED.snoozed_until,
+		    position = CASE WHEN $4 THEN EXCLUDED.position ELSE read_later.position END
+		RETURNING article_id, user_id, position, snoozed_until, added_at
+	`
+
+	var item domain.ReadLaterItem
+	err = tx.QueryRow(ctx, query, userID, articleID, snoozeUntil, top).Scan(
+		&item.ArticleID,
+		&item.UserID,
+		&item.Position,
+		&item.SnoozedUntil,
+		&item.AddedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("adding to read later: %w", err)
+	}
+
+	if snoozeUntil != nil {
+		// Hide until the snooze expires; the trigger keeps snoozed items queued
+		if _, err := tx.Exec(ctx, "UPDATE articles SET is_read = true, read_at = COALESCE(read_at, NOW()) WHERE id = $1", articleID); err != nil {
+			return nil, fmt.Errorf("snoozing article: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing read later: %w", err)
+	}
+
+	return &item, nil
+}
+
+// Remove takes an article out of the user's queue and reports whether it
+// was queued.
+func (r *ReadLaterRepository) Remove(userID, articleID uuid.UUID) (bool, error) {
+	ctx := context.Background()
+
+	tag, err := r.pool.Exec(ctx, "DELETE FROM read_later WHERE user_id = $1 AND article_id = $2", userID, articleID)
+	if err != nil {
+		return false, fmt.Errorf("removing from read later: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// List returns the user's queue in order. Snoozed items are only included
+// when includeSnoozed is set.
+func (r *ReadLaterRepository) List(userID uuid.UUID, includeSnoozed bool, limit, offset int) ([]*domain.ReadLaterItem, error) {
+	ctx := context.Background()
+
+	query := `
+		SELECT article_id, user_id, position, snoozed_until, added_at
+		FROM read_later
+		WHERE user_id = $1 AND ($2 OR snoozed_until IS NULL OR snoozed_until <= NOW())
+		ORDER BY position ASC, added_at ASC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID, includeSnoozed, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("querying read later: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*domain.ReadLaterItem
+	for rows.Next() {
+		var item domain.ReadLaterItem
+		if err := rows.Scan(&item.ArticleID, &item.UserID, &item.Position, &item.SnoozedUntil, &item.AddedAt); err != nil {
+			return nil, fmt.Errorf("scanning read later item: %w", err)
+		}
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
+
+// Reorder moves the given articles to the front of the user's queue in the
+// given order. Queued articles not listed keep their relative order after
+// them.
+func (r *ReadLaterRepository) Reorder(userID uuid.UUID, articleIDs []uuid.UUID) error {
+	ctx := context.Background()
+
+	query := `
+		UPDATE read_later rl
+		SET position = o.ord - 1 - $3::float8 + COALESCE((SELECT MIN(position) FROM read_later WHERE user_id = $1), 0)
+		FROM unnest($2::uuid[]) WITH ORDINALITY AS o(id, ord)
+		WHERE rl.article_id = o.id AND rl.user_id = $1
+	`
+
+	_, err := r.pool.Exec(ctx, query, userID, articleIDs, len(articleIDs))
+	if err != nil {
+		return fmt.Errorf("reordering read later: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseDue brings back articles whose snooze expired: they become unread
+// and stay in the queue. It returns the released article IDs per user.
+func (r *ReadLaterRepository) ReleaseDue(ctx context.Context) (map[uuid.UUID][]uuid.UUID, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		UPDATE read_later
+		SET snoozed_until = NULL
+		WHERE snoozed_until IS NOT NULL AND snoozed_until <= NOW()
+		RETURNING user_id, article_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("releasing snoozed articles: %w", err)
+	}
+
+	released := make(map[uuid.UUID][]uuid.UUID)
+	var ids []uuid.UUID
+	for rows.Next() {
+		var userID, articleID uuid.UUID
+		if err := rows.Scan(&userID, &articleID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scanning released article: %w", err)
+		}
+		released[userID] = append(released[userID], articleID)
+		ids = append(ids, articleID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("releasing snoozed articles: %w", err)
+	}
+
+	if len(ids) > 0 {
+		if _, err := tx.Exec(ctx, "UPDATE articles SET is_read = false, read_at = NULL WHERE id = ANY($1)", ids); err != nil {
+			return nil, fmt.Errorf("marking released articles unread: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing release: %w", err)
+	}
+
+	return released, nil
+}
@@ -0,0 +1,291 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/michael/flowreader/internal/domain"
+)
+
+// RetentionRepository implements domain.RetentionRepository using PostgreSQL.
+type RetentionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRetentionRepository creates a new retention policy repository.
+func NewRetentionRepository(pool *pgxpool.Pool) *RetentionRepository {
+	return &RetentionRepository{pool: pool}
+}
+
+const retentionPolicyColumns = `rp.id, rp.scope, rp.user_id, rp.feed_id, rp.max_age_days, rp.max_articles, rp.keep_unread, rp.created_at, rp.updated_at`
+
+// Create inserts a new retention policy.
+func (r *RetentionRepository) Create(policy *domain.RetentionPolicy) error {
+	ctx := context.Background()
+
+	query := `
+		INSERT INTO retention_policies (id, scope, user_id, feed_id, max_age_days, max_articles, keep_unread, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		policy.ID,
+		string(policy.Scope),
+		policy.UserID,
+		policy.FeedID,
+		policy.MaxAgeDays,
+		policy.MaxArticles,
+		policy.KeepUnread,
+		policy.CreatedAt,
+		policy.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("creating retention policy: %w", err)
+	}
+
+	return nil
+}
+
+// Update saves the limits of a retention policy.
+func (r *RetentionRepository) Update(policy *domain.RetentionPolicy) error {
+	ctx := context.Background()
+
+	query := `
+		UPDATE retention_policies
+		SET max_age_days = $2, max_articles = $3, keep_unread = $4, updated_at = $5
+		WHERE id = $1
+	`
+
+	_, err := r.pool.Exec(ctx, query, policy.ID, policy.MaxAgeDays, policy.MaxArticles, policy.KeepUnread, policy.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("updating retention policy: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a retention policy.
+func (r *RetentionRepository) Delete(id uuid.UUID) error {
+	ctx := context.Background()
+
+	_, err := r.pool.Exec(ctx, "DELETE FROM retention_policies WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("deleting retention policy: %w", err)
+	}
+
+	return nil
+}
+
+// GetInstance retrieves the instance-wide retention policy.
+func (r *RetentionRepository) GetInstance() (*domain.RetentionPolicy, error) {
+	return r.getOne(`SELECT ` + retentionPolicyColumns + ` FROM retention_policies rp WHERE rp.scope = 'instance'`)
+}
+
+// GetForUser retrieves the user-level retention policy of a user.
+func (r *RetentionRepository) GetForUser(userID uuid.UUID) (*domain.RetentionPolicy, error) {
+	return r.getOne(`SELECT `+retentionPolicyColumns+` FROM retention_policies rp WHERE rp.scope = 'user' AND rp.user_id = $1`, userID)
+}
+
+// GetForFeed retrieves the retention policy of a feed.
+func (r *RetentionRepository) GetForFeed(feedID uuid.UUID) (*domain.RetentionPolicy, error) {
+	return r.getOne(`SELECT `+retentionPolicyColumns+` FROM retention_policies rp WHERE rp.scope = 'feed' AND rp.feed_id = $1`, feedID)
+}
+
+func (r *RetentionRepository) getOne(query string, args ...interface{}) (*domain.RetentionPolicy, error) {
+	ctx := context.Background()
+
+	policy, err := scanRetentionPolicy(r.pool.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting retention policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+// ListFeedPolicies retrieves the feed-level retention policies of a user.
+func (r *RetentionRepository) ListFeedPolicies(userID uuid.UUID) ([]*domain.RetentionPolicy, error) {
+	ctx := context.Background()
+
+	query := `
+		SELECT ` + retentionPolicyColumns + `
+		FROM retention_policies rp
+		JOIN feeds f ON f.id = rp.feed_id
+		WHERE rp.scope = 'feed' AND rp.user_id = $1
+		ORDER BY LOWER(f.title) ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("querying retention policies: %w", err)
+	}
+	defer rows.Close()
+
+	var policies []*domain.RetentionPolicy
+	for rows.Next() {
+		policy, err := scanRetentionPolicy(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning retention policy: %w", err)
+		}
+		policies = append(policies, policy)
+	}
+
+	return policies, rows.Err()
+}
+
+// retentionEffectiveCTE resolves the effective policy of every feed: its
+// feed policy, else its owner's policy, else the instance policy. Feeds
+// without any policy are kept. %s receives an extra feed condition.
+const retentionEffectiveCTE = `
+	effective AS (
+		SELECT f.id AS feed_id, p.id AS policy_id, p.max_age_days, p.max_articles, p.keep_unread
+		FROM feeds f
+		CROSS JOIN LATERAL (
+			SELECT rp.id, rp.max_age_days, rp.max_articles, rp.keep_unread
+			FROM retention_policies rp
+			WHERE (rp.scope = 'feed' AND rp.feed_id = f.id)
+			   OR (rp.scope = 'user' AND rp.user_id = f.user_id)
+			   OR rp.scope = 'instance'
+			ORDER BY CASE rp.scope WHEN 'feed' THEN 0 WHEN 'user' THEN 1 ELSE 2 END
+			LIMIT 1
+		) p
+		WHERE TRUE %s
+	)`
+
+// retentionCandidatesCTE selects the articles the effective policies delete.
+// Articles are ranked within their feed, newest ingested first, for the
+// max_articles limit. Protected articles are never candidates.
+const retentionCandidatesCTE = `
+	candidates AS (
+		SELECT a.id, a.feed_id
+		FROM (
+			SELECT a.id, a.feed_id, a.created_at, a.is_read, a.is_favorite,
+			       row_number() OVER (
+			           PARTITION BY a.feed_id
+			           ORDER BY a.created_at DESC, a.published_at DESC NULLS LAST, a.id DESC
+			       ) AS position
+			FROM articles a
+			WHERE a.feed_id IN (
+				SELECT feed_id FROM effective
+				WHERE max_age_days IS NOT NULL OR max_articles IS NOT NULL
+			)
+		) a
+		JOIN effective e ON e.feed_id = a.feed_id
+		WHERE ((e.max_age_days IS NOT NULL AND a.created_at < NOW() - make_interval(days => e.max_age_days))
+		    OR (e.max_articles IS NOT NULL AND a.position > e.max_articles))
+		  AND NOT (e.keep_unread AND NOT a.is_read)
+		  AND ` + retentionKeepClause + `
+	)`
+
+// retentionKeepClause excludes the articles users chose to keep: favorites,
+// tagged, highlighted and archived articles and the read-later queue.
+const retentionKeepClause = `a.is_favorite = false
+		  AND NOT EXISTS (SELECT 1 FROM highlights h WHERE h.article_id = a.id)
+		  AND NOT EXISTS (SELECT 1 FROM article_tags at WHERE at.article_id = a.id)
+		  AND NOT EXISTS (SELECT 1 FROM read_later rl WHERE rl.article_id = a.id)
+		  AND NOT EXISTS (SELECT 1 FROM article_archives aa WHERE aa.article_id = a.id)`
+
+// PurgeCounts reports, for each policy in effect, how many feeds it governs
+// and how many articles Purge would delete from them. With a user ID only
+// that user's feeds are considered.
+func (r *RetentionRepository) PurgeCounts(ctx context.Context, userID uuid.UUID) ([]*domain.RetentionPurgeCount, error) {
+	var args []interface{}
+	feedCond := ""
+	if userID != uuid.Nil {
+		args = append(args, userID)
+		feedCond = "AND f.user_id = $1"
+	}
+
+	query := `
+		WITH ` + fmt.Sprintf(retentionEffectiveCTE, feedCond) + `,
+		` + retentionCandidatesCTE + `
+		SELECT ` + retentionPolicyColumns + `, p.feeds, COALESCE(c.articles, 0)
+		FROM (
+			SELECT policy_id, COUNT(*) AS feeds FROM effective GROUP BY policy_id
+		) p
+		JOIN retention_policies rp ON rp.id = p.policy_id
+		LEFT JOIN (
+			SELECT e.policy_id, COUNT(*) AS articles
+			FROM candidates c
+			JOIN effective e ON e.feed_id = c.feed_id
+			GROUP BY e.policy_id
+		) c ON c.policy_id = rp.id
+		ORDER BY CASE rp.scope WHEN 'instance' THEN 0 WHEN 'user' THEN 1 ELSE 2 END, rp.created_at, rp.id
+	`
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("counting retention candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []*domain.RetentionPurgeCount
+	for rows.Next() {
+		var count domain.RetentionPurgeCount
+		var policy domain.RetentionPolicy
+		var scope string
+		if err := rows.Scan(
+			&policy.ID,
+			&scope,
+			&policy.UserID,
+			&policy.FeedID,
+			&policy.MaxAgeDays,
+			&policy.MaxArticles,
+			&policy.KeepUnread,
+			&policy.CreatedAt,
+			&policy.UpdatedAt,
+			&count.Feeds,
+			&count.Articles,
+		); err != nil {
+			return nil, fmt.Errorf("scanning retention count: %w", err)
+		}
+		policy.Scope = domain.RetentionScope(scope)
+		count.Policy = &policy
+		counts = append(counts, &count)
+	}
+
+	return counts, rows.Err()
+}
+
+// Purge deletes the articles that the retention policies no longer keep.
+func (r *RetentionRepository) Purge(ctx context.Context) (int64, error) {
+	query := `
+		WITH ` + fmt.Sprintf(retentionEffectiveCTE, "") + `,
+		` + retentionCandidatesCTE + `
+		DELETE FROM articles
+		WHERE id IN (SELECT id FROM candidates)
+	`
+
+	result, err := r.pool.Exec(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("purging articles: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func scanRetentionPolicy(row pgx.Row) (*domain.RetentionPolicy, error) {
+	var policy domain.RetentionPolicy
+	var scope string
+	if err := row.Scan(
+		&policy.ID,
+		&scope,
+		&policy.UserID,
+		&policy.FeedID,
+		&policy.MaxAgeDays,
+		&policy.MaxArticles,
+		&policy.KeepUnread,
+		&policy.CreatedAt,
+		&policy.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	policy.Scope = domain.RetentionScope(scope)
+	return &policy, nil
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/michael/flowreader/internal/domain"
+)
+
+// SavedSearchRepository implements domain.SavedSearchRepository using PostgreSQL.
+type SavedSearchRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSavedSearchRepository creates a new saved search repository.
+func NewSavedSearchRepository(pool *pgxpool.Pool) *SavedSearchRepository {
+	return &SavedSearchRepository{pool: pool}
+}
+
+const savedSearchColumns = `id, user_id, name, query, filters, position, created_at, updated_at`
+
+// Create inserts a new saved search.
+func (r *SavedSearchRepository) Create(search *domain.SavedSearch) error {
+	ctx := context.Background()
+
+	filters, err := json.Marshal(search.Filters)
+	if err != nil {
+		return fmt.Errorf("encoding saved search filters: %w", err)
+	}
+
+	query := `
+		INSERT INTO saved_searches (id, user_id, name, query, filters, position, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err = r.pool.Exec(ctx, query,
+		search.ID,
+		search.UserID,
+		search.Name,
+		search.Query,
+		filters,
+		search.Position,
+		search.CreatedAt,
+		search.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("creating saved search: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a saved search by its ID.
+func (r *SavedSearchRepository) GetByID(id uuid.UUID) (*domain.SavedSearch, error) {
+	ctx := context.Background()
+
+	query := `SELECT ` + savedSearchColumns + ` FROM saved_searches WHERE id = $1`
+
+	search, err := scanSavedSearch(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting saved search by ID: %w", err)
+	}
+
+	return search, nil
+}
+
+// GetByUserID retrieves all saved searches of a user in sidebar order.
+func (r *SavedSearchRepository) GetByUserID(userID uuid.UUID) ([]*domain.SavedSearch, error) {
+	ctx := context.Background()
+
+	query := `
+		SELECT ` + savedSearchColumns + `
+		FROM saved_searches
+		WHERE user_id = $1
+		ORDER BY position ASC, LOWER(name) ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("querying saved searches: %w", err)
+	}
+	defer rows.Close()
+
+	var searches []*domain.SavedSearch
+	for rows.Next() {
+		search, err := scanSavedSearch(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning saved search: %w", err)
+		}
+		searches = append(searches, search)
+	}
+
+	return searches, rows.Err()
+}
+
+// GetByName retrieves a user's saved search by name (case-insensitive).
+func (r *SavedSearchRepository) GetByName(userID uuid.UUID, name string) (*domain.SavedSearch, error) {
+	ctx := context.Background()
+
+	query := `SELECT ` + savedSearchColumns + ` FROM saved_searches WHERE user_id = $1 AND LOWER(name) = LOWER($2)`
+
+	search, err := scanSavedSearch(r.pool.QueryRow(ctx, query, userID, name))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting saved search by name: %w", err)
+	}
+
+	return search, nil
+}
+
+// Update saves the name, query, filters and position of a saved search.
+func (r *SavedSearchRepository) Update(search *domain.SavedSearch) error {
+	ctx := context.Background()
+
+	filters, err := json.Marshal(search.Filters)
+	if err != nil {
+		return fmt.Errorf("encoding saved search filters: %w", err)
+	}
+
+	query := `
+		UPDATE saved_searches
+		SET name = $2, query = $3, filters = $4, position = $5, updated_at = $6
+		WHERE id = $1
+	`
+
+	_, err = r.pool.Exec(ctx, query, search.ID, search.Name, search.Query, filters, search.Position, search.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("updating saved search: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a saved search.
+func (r *SavedSearchRepository) Delete(id uuid.UUID) error {
+	ctx := context.Background()
+
+	_, err := r.pool.Exec(ctx, "DELETE FROM saved_searches WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("deleting saved search: %w", err)
+	}
+
+	return nil
+}
+
+func scanSavedSearch(row pgx.Row) (*domain.SavedSearch, error) {
+	var search domain.SavedSearch
+	var filters []byte
+	if err := row.Scan(
+		&search.ID,
+		&search.UserID,
+		&search.Name,
+		&search.Query,
+		&filters,
+		&search.Position,
+		&search.CreatedAt,
+		&search.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(filters, &search.Filters); err != nil {
+		return nil, fmt.Errorf("decoding saved search filters: %w", err)
+	}
+	return &search, nil
+}
@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/michael/flowreader/internal/domain"
+)
+
+// TagRepository implements domain.TagRepository using PostgreSQL.
+type TagRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewTagRepository creates a new tag repository.
+func NewTagRepository(pool *pgxpool.Pool) *TagRepository {
+	return &TagRepository{pool: pool}
+}
+
+// Create inserts a new tag. It returns false, without error, when the user
+// already has a tag with the same name, including one created concurrently.
+func (r *TagRepository) Create(tag *domain.Tag) (bool, error) {
+	ctx := context.Background()
+
+	query := `
+		INSERT INTO tags (id, user_id, name, color, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT DO NOTHING
+		RETURNING id
+	`
+
+	var id uuid.UUID
+	err := r.pool.QueryRow(ctx, query, tag.ID, tag.UserID, tag.Name, nullString(tag.Color), tag.CreatedAt).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("creating tag: %w", err)
+	}
+
+	return true, nil
+}
+
+// GetByID retrieves a tag by its ID.
+func (r *TagRepository) GetByID(id uuid.UUID) (*domain.Tag, error) {
+	ctx := context.Background()
+
+	query := `SELECT id, user_id, name, color, created_at FROM tags WHERE id = $1`
+
+	tag, err := scanTag(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting tag by ID: %w", err)
+	}
+
+	return tag, nil
+}
+
+// GetByUserID retrieves all tags of a user with their article counts.
+func (r *TagRepository) GetByUserID(userID uuid.UUID) ([]*domain.Tag, error) {
+	ctx := context.Background()
+
+	query := `
+		SELECT t.id, t.user_id, t.name, t.color, t.created_at,
+		       COUNT(a.id) AS article_count,
+		       COUNT(a.id) FILTER (WHERE a.is_read = false) AS unread_count
+		FROM tags t
+		LEFT JOIN article_tags at ON at.tag_id = t.id
+		LEFT JOIN articles a ON a.id = at.article_id
+		WHERE t.user_id = $1
+		GROUP BY t.id
+		ORDER BY LOWER(t.name) ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("querying tags: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []*domain.Tag
+	for rows.Next() {
+		var tag domain.Tag
+		var color *string
+		if err := rows.Scan(&tag.ID, &tag.UserID, &tag.Name, &color, &tag.CreatedAt, &tag.ArticleCount, &tag.UnreadCount); err != nil {
+			return nil, fmt.Errorf("scanning tag: %w", err)
+		}
+		if color != nil {
+			tag.Color = *color
+		}
+		tags = append(tags, &tag)
+	}
+
+	return tags, nil
+}
+
+// GetByNames retrieves a user's tags by name (case-insensitive).
+func (r *TagRepository) GetByNames(userID uuid.UUID, names []string) ([]*domain.Tag, error) {
+	ctx := context.Background()
+
+	lowered := make([]string, len(names))
+	for i, name := range names {
+		lowered[i] = strings.ToLower(name)
+	}
+
+	query := `
+		SELECT id, user_id, name, color, created_at
+		FROM tags
+		WHERE user_id = $1 AND LOWER(name) = ANY($2)
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID, lowered)
+	if err != nil {
+		return nil, fmt.Errorf("querying tags by name: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []*domain.Tag
+	for rows.Next() {
+		tag, err := scanTag(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// Update renames or recolors a tag. It returns domain.ErrTagExists when the
+// new name is taken by another of the user's tags.
+func (r *TagRepository) Update(tag *domain.Tag) error {
+	ctx := context.Background()
+
+	_, err := r.pool.Exec(ctx, "UPDATE tags SET name = $2, color = $3 WHERE id = $1", tag.ID, tag.Name, nullString(tag.Color))
+	if err != nil {
+		// unique_violation on idx_tags_user_name
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrTagExists
+		}
+		return fmt.Errorf("updating tag: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a tag and its article associations (cascaded by DB).
+func (r *TagRepository) Delete(id uuid.UUID) error {
+	ctx := context.Background()
+	_, err := r.pool.Exec(ctx, "DELETE FROM tags WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("deleting tag: %w", err)
+	}
+	return nil
+}
+
+// AddToArticles applies the tags to the user's articles in one statement and
+// returns the IDs of the articles that were found.
+func (r *TagRepository) AddToArticles(userID uuid.UUID, tagIDs, articleIDs []uuid.UUID) ([]uuid.UUID, error) {
+	ctx := context.Background()
+
+	query := `
+		WITH owned AS (
+			SELECT a.id
+			FROM articles a
+			JOIN feeds f ON f.id = a.feed_id
+			WHERE f.user_id = $1 AND a.id = ANY($3)
+		), inserted AS (
+			INSERT INTO article_tags (article_id, tag_id)
+			SELECT owned.id, t.id
+			FROM owned
+			JOIN tags t ON t.user_id = $1 AND t.id = ANY($2)
+			ON CONFLICT DO NOTHING
+		)
+		SELECT id FROM owned
+	`
+
+	return r.queryIDs(ctx, query, userID, tagIDs, articleIDs)
+}
+
+// RemoveFromArticles removes the tags from the user's articles and returns
+// the IDs of the articles that were found.
+func (r *TagRepository) RemoveFromArticles(userID uuid.UUID, tagIDs, articleIDs []uuid.UUID) ([]uuid.UUID, error) {
+	ctx := context.Background()
+
+	query := `
+		WITH owned AS (
+			SELECT a.id
+			FROM articles a
+			JOIN feeds f ON f.id = a.feed_id
+			WHERE f.user_id = $1 AND a.id = ANY($3)
+		), deleted AS (
+			DELETE FROM article_tags
+			WHERE article_id IN (SELECT id FROM owned) AND tag_id = ANY($2)
+		)
+		SELECT id FROM owned
+	`
+
+	return r.queryIDs(ctx, query, userID, tagIDs, articleIDs)
+}
+
+// SetForArticle replaces the tags of an article.
+func (r *TagRepository) SetForArticle(articleID uuid.UUID, tagIDs []uuid.UUID) error {
+	ctx := context.Background()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "DELETE FROM article_tags WHERE article_id = $1 AND NOT (tag_id = ANY($2))", articleID, tagIDs); err != nil {
+		return fmt.Errorf("removing article tags: %w", err)
+	}
+
+	query := `
+		INSERT INTO article_tags (article_id, tag_id)
+		SELECT $1, unnest($2::uuid[])
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := tx.Exec(ctx, query, articleID, tagIDs); err != nil {
+		return fmt.Errorf("adding article tags: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *TagRepository) queryIDs(ctx context.Context, query string, args ...interface{}) ([]uuid.UUID, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("updating article tags: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scanning article ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// scanTag scans a single tag row.
+func scanTag(row pgx.Row) (*domain.Tag, error) {
+	var tag domain.Tag
+	var color *string
+	if err := row.Scan(&tag.ID, &tag.UserID, &tag.Name, &color, &tag.CreatedAt); err != nil {
+		return nil, err
+	}
+	if color != nil {
+		tag.Color = *color
+	}
+	return &tag, nil
+}
@@ -64,7 +64,7 @@ func (r *UserRepository) GetByEmail(email string) (*domain.User, error) {
 	ctx := context.Background()
 
 	query := `
-		SELECT id, email, password_hash, created_at, role
+		SELECT id, email, password_hash, created_at, role, proxy_images
 		FROM users
 		WHERE email = $1
 	`
@@ -76,6 +76,7 @@ func (r *UserRepository) GetByEmail(email string) (*domain.User, error) {
 		&user.PasswordHash,
 		&user.CreatedAt,
 		&user.Role,
+		&user.ProxyImages,
 	)
 
 	if err != nil {
@@ -93,7 +94,7 @@ func (r *UserRepository) GetByID(id uuid.UUID) (*domain.User, error) {
 	ctx := context.Background()
 
 	query := `
-		SELECT id, email, password_hash, created_at, role
+		SELECT id, email, password_hash, created_at, role, proxy_images
 		FROM users
 		WHERE id = $1
 	`
@@ -105,6 +106,7 @@ func (r *UserRepository) GetByID(id uuid.UUID) (*domain.User, error) {
 		&user.PasswordHash,
 		&user.CreatedAt,
 		&user.Role,
+		&user.ProxyImages,
 	)
 
 	if err != nil {
@@ -150,6 +152,16 @@ func (r *UserRepository) Delete(id uuid.UUID) error {
 	return nil
 }
 
+// UpdatePreferences stores the user's reading preferences.
+func (r *UserRepository) UpdatePreferences(id uuid.UUID, proxyImages bool) error {
+	ctx := context.Background()
+	_, err := r.pool.Exec(ctx, "UPDATE users SET proxy_images = $2 WHERE id = $1", id, proxyImages)
+	if err != nil {
+		return fmt.Errorf("updating preferences: %w", err)
+	}
+	return nil
+}
+
 // Exists checks if an email already exists.
 func (r *UserRepository) Exists(email string) (bool, error) {
 	ctx := context.Background()
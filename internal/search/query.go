@@ -0,0 +1,364 @@
+// Package search parses the article search syntax: full-text terms in the
+// websearch_to_tsquery syntax mixed with operators such as feed:, author:,
+// tag:, is:, has:, before: and after:.
+package search
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/michael/flowreader/internal/domain"
+)
+
+// ErrorCode identifies the kind of a query syntax error.
+type ErrorCode string
+
+// Query syntax error codes.
+const (
+	ErrUnknownOperator   ErrorCode = "unknown_operator"
+	ErrMissingValue      ErrorCode = "missing_value"
+	ErrInvalidValue      ErrorCode = "invalid_value"
+	ErrInvalidNegation   ErrorCode = "invalid_negation"
+	ErrConflict          ErrorCode = "conflicting_operators"
+	ErrUnterminatedQuote ErrorCode = "unterminated_quote"
+)
+
+// Error is a query syntax error. Position is the byte offset of the
+// offending token in the query.
+type Error struct {
+	Code     ErrorCode `json:"code"`
+	Message  string    `json:"message"`
+	Operator string    `json:"operator,omitempty"`
+	Value    string    `json:"value,omitempty"`
+	Position int       `json:"position"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Message, e.Position)
+}
+
+// Query is a parsed search query. Text holds the remaining full-text terms,
+// including phrases, OR and -excluded words, for websearch_to_tsquery.
+type Query struct {
+	Text string
+
+	Feeds          []string
+	ExcludeFeeds   []string
+	Authors        []string
+	ExcludeAuthors []string
+	Tags           []string
+	ExcludeTags    []string
+
+	IsRead     *bool
+	IsFavorite *bool
+	HasImage   *bool
+	Before     *time.Time
+	After      *time.Time
+}
+
+// Operators recognized by the parser.
+var operators = []string{"feed", "author", "tag", "is", "has", "before", "after"}
+
+// Parse parses a search query.
+func Parse(input string) (*Query, error) {
+	q := &Query{}
+	var text []string
+
+	i := 0
+	for i < len(input) {
+		if isSpace(input[i]) {
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(input) && !isSpace(input[i]) {
+			if input[i] == '"' {
+				end := strings.IndexByte(input[i+1:], '"')
+				if end < 0 {
+					return nil, &Error{
+						Code:     ErrUnterminatedQuote,
+						Message:  "Unterminated quote",
+						Position: i,
+					}
+				}
+				i += end + 2
+				continue
+			}
+			i++
+		}
+
+		token := input[start:i]
+		isOperator, err := q.applyToken(token, start)
+		if err != nil {
+			return nil, err
+		}
+		if !isOperator {
+			text = append(text, token)
+		}
+	}
+
+	q.Text = strings.Join(text, " ")
+	return q, nil
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// applyToken applies an operator token to the query. It reports false for
+// full-text tokens.
+func (q *Query) applyToken(token string, pos int) (bool, error) {
+	body := token
+	negated := strings.HasPrefix(body, "-")
+	if negated {
+		body = body[1:]
+	}
+
+	colon := strings.IndexByte(body, ':')
+	if colon <= 0 || !isOperatorName(body[:colon]) {
+		return false, nil
+	}
+	name := strings.ToLower(body[:colon])
+	value := body[colon+1:]
+
+	// URLs are search terms, not operators
+	if strings.HasPrefix(value, "//") {
+		return false, nil
+	}
+
+	if !isOperator(name) {
+		// Only a likely typo of an operator is an error: other words with a
+		// colon, such as "re:Invent" or "std::vector", are search terms
+		suggestion := suggestOperator(name)
+		if suggestion == "" {
+			return false, nil
+		}
+		return true, &Error{
+			Code:     ErrUnknownOperator,
+			Message:  fmt.Sprintf("Unknown operator %q (did you mean %s:?)", name, suggestion),
+			Operator: name,
+			Position: pos,
+		}
+	}
+
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		value = value[1 : len(value)-1]
+	}
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return true, &Error{
+			Code:     ErrMissingValue,
+			Message:  fmt.Sprintf("Missing value for %s:", name),
+			Operator: name,
+			Position: pos,
+		}
+	}
+
+	invalid := func(message string) error {
+		return &Error{
+			Code:     ErrInvalidValue,
+			Message:  message,
+			Operator: name,
+			Value:    value,
+			Position: pos,
+		}
+	}
+
+	switch name {
+	case "feed":
+		if negated {
+			q.ExcludeFeeds = append(q.ExcludeFeeds, value)
+		} else {
+			q.Feeds = append(q.Feeds, value)
+		}
+
+	case "author":
+		if negated {
+			q.ExcludeAuthors = append(q.ExcludeAuthors, value)
+		} else {
+			q.Authors = append(q.Authors, value)
+		}
+
+	case "tag":
+		if negated {
+			q.ExcludeTags = append(q.ExcludeTags, value)
+		} else {
+			q.Tags = append(q.Tags, value)
+		}
+
+	case "is":
+		var target **bool
+		var state bool
+		switch strings.ToLower(value) {
+		case "unread":
+			target, state = &q.IsRead, false
+		case "read":
+			target, state = &q.IsRead, true
+		case "starred", "favorite", "favorited":
+			target, state = &q.IsFavorite, true
+		default:
+			return true, invalid(fmt.Sprintf("Invalid value %q for is: (expected unread, read or starred)", value))
+		}
+		if negated {
+			state = !state
+		}
+		if err := setFlag(target, state, name, value, pos); err != nil {
+			return true, err
+		}
+
+	case "has":
+		if strings.ToLower(value) != "image" {
+			return true, invalid(fmt.Sprintf("Invalid value %q for has: (expected image)", value))
+		}
+		if err := setFlag(&q.HasImage, !negated, name, value, pos); err != nil {
+			return true, err
+		}
+
+	case "before", "after":
+		if negated {
+			return true, &Error{
+				Code:     ErrInvalidNegation,
+				Message:  fmt.Sprintf("%s: cannot be negated", name),
+				Operator: name,
+				Value:    value,
+				Position: pos,
+			}
+		}
+		t, err := parseDate(value)
+		if err != nil {
+			return true, invalid(fmt.Sprintf("Invalid date %q for %s: (expected YYYY-MM-DD)", value, name))
+		}
+		if name == "before" {
+			q.Before = &t
+		} else {
+			q.After = &t
+		}
+		if q.Before != nil && q.After != nil && !q.After.Before(*q.Before) {
+			return true, &Error{
+				Code:     ErrConflict,
+				Message:  "after: must be earlier than before:",
+				Operator: name,
+				Value:    value,
+				Position: pos,
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// isOperatorName reports whether s looks like an operator name.
+func isOperatorName(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i] | 0x20
+		if c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// isOperator reports whether name is an operator recognized by the parser.
+func isOperator(name string) bool {
+	for _, op := range operators {
+		if op == name {
+			return true
+		}
+	}
+	return false
+}
+
+// suggestOperator returns the operator one edit away from name (a letter
+// inserted, removed, replaced or two adjacent letters swapped), or "" when
+// name is not a likely typo of an operator. Names shorter than three letters,
+// such as "at" or "re", are too close to too many words to tell.
+func suggestOperator(name string) string {
+	if len(name) < 3 {
+		return ""
+	}
+	for _, op := range operators {
+		if withinOneEdit(name, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// withinOneEdit reports whether the ASCII strings a and b differ by at most
+// one edit.
+func withinOneEdit(a, b string) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	if len(b)-len(a) > 1 {
+		return false
+	}
+
+	i := 0
+	for i < len(a) && a[i] == b[i] {
+		i++
+	}
+	if i == len(a) {
+		return true
+	}
+	if len(a) < len(b) {
+		return a[i:] == b[i+1:]
+	}
+	if a[i+1:] == b[i+1:] {
+		return true
+	}
+	return i+1 < len(a) && a[i] == b[i+1] && a[i+1] == b[i] && a[i+2:] == b[i+2:]
+}
+
+// setFlag sets a boolean condition, rejecting contradictions such as
+// "is:read is:unread".
+func setFlag(target **bool, state bool, name, value string, pos int) error {
+	if *target != nil && **target != state {
+		return &Error{
+			Code:     ErrConflict,
+			Message:  fmt.Sprintf("%s:%s contradicts an earlier operator", name, value),
+			Operator: name,
+			Value:    value,
+			Position: pos,
+		}
+	}
+	*target = &state
+	return nil
+}
+
+// parseDate parses a YYYY-MM-DD date or an RFC 3339 timestamp.
+func parseDate(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// Apply adds the query's conditions to an article filter.
+func (q *Query) Apply(filter *domain.ArticleFilter) {
+	filter.Query = q.Text
+	filter.FeedTitles = append(filter.FeedTitles, q.Feeds...)
+	filter.ExcludeFeedTitles = append(filter.ExcludeFeedTitles, q.ExcludeFeeds...)
+	filter.Authors = append(filter.Authors, q.Authors...)
+	filter.ExcludeAuthors = append(filter.ExcludeAuthors, q.ExcludeAuthors...)
+	filter.Tags = append(filter.Tags, q.Tags...)
+	filter.ExcludeTags = append(filter.ExcludeTags, q.ExcludeTags...)
+
+	if q.IsRead != nil {
+		filter.IsRead = q.IsRead
+	}
+	if q.IsFavorite != nil {
+		filter.IsFavorite = q.IsFavorite
+	}
+	if q.HasImage != nil {
+		filter.HasImage = q.HasImage
+	}
+	if q.After != nil {
+		filter.PublishedAfter = q.After
+	}
+	if q.Before != nil {
+		filter.PublishedBefore = q.Before
+	}
+}
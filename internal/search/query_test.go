@@ -0,0 +1,187 @@
+package search
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/michael/flowreader/internal/domain"
+)
+
+func boolPtr(b bool) *bool { return &b }
+
+func date(t *testing.T, s string) *time.Time {
+	t.Helper()
+	d, err := parseDate(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &d
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  *Query
+	}{
+		{"empty", "", &Query{}},
+		{"text only", "  rust   async ", &Query{Text: "rust async"}},
+		{"text operators kept", `"exact phrase" -excluded OR other`, &Query{Text: `"exact phrase" -excluded OR other`}},
+		{"operators and text mixed", "rust feed:hn is:unread web", &Query{Text: "rust web", Feeds: []string{"hn"}, IsRead: boolPtr(false)}},
+
+		{"feed", "feed:hn", &Query{Feeds: []string{"hn"}}},
+		{"negated feed", "-feed:hn", &Query{ExcludeFeeds: []string{"hn"}}},
+		{"quoted feed", `feed:"Hacker News" rust`, &Query{Text: "rust", Feeds: []string{"Hacker News"}}},
+		{"negated quoted feed", `-feed:"Hacker News"`, &Query{ExcludeFeeds: []string{"Hacker News"}}},
+		{"author", "author:alice", &Query{Authors: []string{"alice"}}},
+		{"negated author", "-author:alice", &Query{ExcludeAuthors: []string{"alice"}}},
+		{"quoted author", `author:"Jane Doe"`, &Query{Authors: []string{"Jane Doe"}}},
+		{"tag", "tag:go", &Query{Tags: []string{"go"}}},
+		{"negated tag", "-tag:go", &Query{ExcludeTags: []string{"go"}}},
+		{"repeated operator", "tag:go tag:rust", &Query{Tags: []string{"go", "rust"}}},
+		{"is unread", "is:unread", &Query{IsRead: boolPtr(false)}},
+		{"negated is unread", "-is:unread", &Query{IsRead: boolPtr(true)}},
+		{"is read", "is:read", &Query{IsRead: boolPtr(true)}},
+		{"negated is read", "-is:read", &Query{IsRead: boolPtr(false)}},
+		{"is starred", "is:starred", &Query{IsFavorite: boolPtr(true)}},
+		{"negated is starred", "-is:starred", &Query{IsFavorite: boolPtr(false)}},
+		{"case insensitive", "IS:Read", &Query{IsRead: boolPtr(true)}},
+		{"agreeing flags", "is:unread -is:read", &Query{IsRead: boolPtr(false)}},
+		{"has image", "has:image", &Query{HasImage: boolPtr(true)}},
+		{"negated has image", "-has:image", &Query{HasImage: boolPtr(false)}},
+		{"after", "after:2024-01-01", &Query{After: date(t, "2024-01-01")}},
+		{"before", "before:2024-02-01", &Query{Before: date(t, "2024-02-01")}},
+		{"date range", "after:2024-01-01 before:2024-02-01", &Query{After: date(t, "2024-01-01"), Before: date(t, "2024-02-01")}},
+		{"timestamp", "after:2024-01-01T10:00:00Z", &Query{After: date(t, "2024-01-01T10:00:00Z")}},
+
+		{"url", "http://example.com/a", &Query{Text: "http://example.com/a"}},
+		{"https url with text", "read https://example.com/a?b=c", &Query{Text: "read https://example.com/a?b=c"}},
+		{"negated url", "-http://example.com", &Query{Text: "-http://example.com"}},
+		{"double colon", "std::vector", &Query{Text: "std::vector"}},
+		{"word with colon", "re:Invent 2024", &Query{Text: "re:Invent 2024"}},
+		{"short word with colon", "at:home", &Query{Text: "at:home"}},
+		{"time", "10:30", &Query{Text: "10:30"}},
+		{"colon first", ":smile:", &Query{Text: ":smile:"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q)\n got %+v\nwant %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		code     ErrorCode
+		operator string
+		position int
+	}{
+		{"unknown operator", "athor:smith", ErrUnknownOperator, "athor", 0},
+		{"unknown operator after text", "rust feeds:hn", ErrUnknownOperator, "feeds", 5},
+		{"negated unknown operator", "go -tags:web", ErrUnknownOperator, "tags", 3},
+		{"swapped letters", "atuhor:x", ErrUnknownOperator, "atuhor", 0},
+
+		{"missing value", "rust feed:", ErrMissingValue, "feed", 5},
+		{"negated missing value", "-tag:", ErrMissingValue, "tag", 0},
+		{"empty quoted value", `author:""`, ErrMissingValue, "author", 0},
+		{"blank quoted value", `author:"  "`, ErrMissingValue, "author", 0},
+
+		{"unterminated quoted value", `rust feed:"Hacker News`, ErrUnterminatedQuote, "", 10},
+		{"unterminated phrase", `"open phrase`, ErrUnterminatedQuote, "", 0},
+		{"unterminated after phrase", `"a b" c "d`, ErrUnterminatedQuote, "", 8},
+
+		{"invalid is", "is:maybe", ErrInvalidValue, "is", 0},
+		{"invalid has", "has:video", ErrInvalidValue, "has", 0},
+		{"invalid date", "x after:yesterday", ErrInvalidValue, "after", 2},
+		{"negated date", "-before:2024-01-01", ErrInvalidNegation, "before", 0},
+
+		{"read and unread", "is:read is:unread", ErrConflict, "is", 8},
+		{"read and not read", "is:read -is:read", ErrConflict, "is", 8},
+		{"image and no image", "has:image -has:image", ErrConflict, "has", 10},
+		{"after later than before", "after:2024-02-01 before:2024-01-01", ErrConflict, "before", 17},
+		{"after equal to before", "before:2024-01-01 after:2024-01-01", ErrConflict, "after", 18},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Parse(tt.input)
+			if err == nil {
+				t.Fatalf("Parse(%q) = %+v, want error %s", tt.input, q, tt.code)
+			}
+			var perr *Error
+			if !errors.As(err, &perr) {
+				t.Fatalf("Parse(%q) error %v is not an *Error", tt.input, err)
+			}
+			if perr.Code != tt.code || perr.Operator != tt.operator || perr.Position != tt.position {
+				t.Errorf("Parse(%q) = {Code: %s, Operator: %q, Position: %d}, want {Code: %s, Operator: %q, Position: %d}",
+					tt.input, perr.Code, perr.Operator, perr.Position, tt.code, tt.operator, tt.position)
+			}
+		})
+	}
+}
+
+func TestWithinOneEdit(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"feed", "feed", true},
+		{"fed", "feed", true},
+		{"feeds", "feed", true},
+		{"fees", "feed", true},
+		{"efed", "feed", true},
+		{"atuhor", "author", true},
+		{"athr", "author", false},
+		{"re", "is", false},
+		{"std", "tag", false},
+		{"", "is", false},
+	}
+
+	for _, tt := range tests {
+		if got := withinOneEdit(tt.a, tt.b); got != tt.want {
+			t.Errorf("withinOneEdit(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if got := withinOneEdit(tt.b, tt.a); got != tt.want {
+			t.Errorf("withinOneEdit(%q, %q) = %v, want %v", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	q, err := Parse(`rust feed:hn -feed:"Slashdot" author:alice -author:bob tag:go -tag:ads is:unread -is:starred has:image after:2024-01-01 before:2024-02-01`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filter := domain.ArticleFilter{Tags: []string{"existing"}}
+	q.Apply(&filter)
+
+	want := domain.ArticleFilter{
+		Query:             "rust",
+		FeedTitles:        []string{"hn"},
+		ExcludeFeedTitles: []string{"Slashdot"},
+		Authors:           []string{"alice"},
+		ExcludeAuthors:    []string{"bob"},
+		Tags:              []string{"existing", "go"},
+		ExcludeTags:       []string{"ads"},
+		IsRead:            boolPtr(false),
+		IsFavorite:        boolPtr(false),
+		HasImage:          boolPtr(true),
+		PublishedAfter:    date(t, "2024-01-01"),
+		PublishedBefore:   date(t, "2024-02-01"),
+	}
+	if !reflect.DeepEqual(filter, want) {
+		t.Errorf("Apply\n got %+v\nwant %+v", filter, want)
+	}
+}
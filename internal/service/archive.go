@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/google/uuid"
+	"github.com/michael/flowreader/internal/domain"
+)
+
+// Archive service errors
+var (
+	ErrArchiveNotFound = errors.New("archive not found")
+)
+
+// Archive limits
+const (
+	// maxArchiveImages and maxArchiveBytes bound the size of a snapshot;
+	// images past either limit keep their remote URL.
+	maxArchiveImages = 100
+	maxArchiveBytes  = 32 << 20
+
+	// archiveTimeout bounds the extraction and image downloads of one article.
+	archiveTimeout = 2 * time.Minute
+)
+
+// ArchiveService captures permanent, self-contained snapshots of articles.
+// The content is the page extracted by the reader view (the feed's copy when
+// extraction fails) with every image downloaded through the SSRF-safe image
+// proxy and inlined as a data URI.
+type ArchiveService struct {
+	archiveRepo   domain.ArchiveRepository
+	articleRepo   domain.ArticleRepository
+	feedRepo      domain.FeedRepository
+	readerService *ReaderService
+	imageProxy    *ImageProxyService
+}
+
+// NewArchiveService creates a new archive service.
+func NewArchiveService(archiveRepo domain.ArchiveRepository, articleRepo domain.ArticleRepository, feedRepo domain.FeedRepository, readerService *ReaderService, imageProxy *ImageProxyService) *ArchiveService {
+	return &ArchiveService{
+		archiveRepo:   archiveRepo,
+		articleRepo:   articleRepo,
+		feedRepo:      feedRepo,
+		readerService: readerService,
+		imageProxy:    imageProxy,
+	}
+}
+
+// ownedArticle returns an article if it belongs to the user.
+func (s *ArchiveService) ownedArticle(articleID, userID uuid.UUID) (*domain.Article, error) {
+	article, err := s.articleRepo.GetByID(articleID)
+	if err != nil {
+		return nil, fmt.Errorf("getting article: %w", err)
+	}
+	if article == nil {
+		return nil, ErrArticleNotFound
+	}
+
+	feed, err := s.feedRepo.GetByID(article.FeedID)
+	if err != nil {
+		return nil, fmt.Errorf("getting feed: %w", err)
+	}
+	if feed == nil || feed.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	return article, nil
+}
+
+// GetArchive returns the archive of one of the user's articles.
+func (s *ArchiveService) GetArchive(articleID, userID uuid.UUID) (*domain.ArticleArchive, error) {
+	if _, err := s.ownedArticle(articleID, userID); err != nil {
+		return nil, err
+	}
+
+	archive, err := s.archiveRepo.Get(articleID)
+	if err != nil {
+		return nil, fmt.Errorf("getting archive: %w", err)
+	}
+	if archive == nil {
+		return nil, ErrArchiveNotFound
+	}
+
+	return archive, nil
+}
+
+// Archive captures (or refreshes) the snapshot of one of the user's articles.
+func (s *ArchiveService) Archive(ctx context.Context, articleID, userID uuid.UUID) (*domain.ArticleArchive, error) {
+	article, err := s.ownedArticle(articleID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.archive(ctx, article, userID)
+}
+
+// DeleteArchive removes the snapshot of one of the user's articles.
+func (s *ArchiveService) DeleteArchive(articleID, userID uuid.UUID) error {
+	if _, err := s.ownedArticle(articleID, userID); err != nil {
+		return err
+	}
+
+	deleted, err := s.archiveRepo.Delete(articleID)
+	if err != nil {
+		return fmt.Errorf("deleting archive: %w", err)
+	}
+	if !deleted {
+		return ErrArchiveNotFound
+	}
+
+	return nil
+}
+
+// ArchivePendingFavorites archives up to limit favorite articles that have no
+// snapshot yet and returns how many it looked at.
+func (s *ArchiveService) ArchivePendingFavorites(ctx context.Context, limit int) (int, error) {
+	ids, err := s.archiveRepo.PendingFavorites(limit)
+	if err != nil {
+		return 0, fmt.Errorf("getting pending favorites: %w", err)
+	}
+
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		article, err := s.articleRepo.GetByID(id)
+		if err != nil {
+			return 0, fmt.Errorf("getting article: %w", err)
+		}
+		if article == nil {
+			continue
+		}
+		feed, err := s.feedRepo.GetByID(article.FeedID)
+		if err != nil {
+			return 0, fmt.Errorf("getting feed: %w", err)
+		}
+		if feed == nil {
+			continue
+		}
+
+		if _, err := s.archive(ctx, article, feed.UserID); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(ids), nil
+}
+
+// archive builds and stores the snapshot of an article.
+func (s *ArchiveService) archive(ctx context.Context, article *domain.Article, userID uuid.UUID) (*domain.ArticleArchive, error) {
+	ctx, cancel := context.WithTimeout(ctx, archiveTimeout)
+	defer cancel()
+
+	// Feed content is sanitized at ingest, extracted content by the reader
+	content, source := article.Content, domain.ArchiveSourceFeed
+	if content == "" {
+		content = article.Summary
+	}
+	if article.URL != "" {
+		fullText, err := s.readerService.GetFullText(ctx, article, false)
+		if err != nil {
+			log.Printf("Archive: extraction failed for article %s, keeping the feed copy: %v", article.ID, err)
+		} else if strings.TrimSpace(fullText.Content) != "" {
+			content, source = fullText.Content, domain.ArchiveSourceExtracted
+		}
+	}
+
+	content, inlined, failed := s.inlineImages(ctx, content, article.URL)
+
+	archive := &domain.ArticleArchive{
+		ArticleID:        article.ID,
+		UserID:           userID,
+		Title:            article.Title,
+		URL:              article.URL,
+		Author:           article.Author,
+		PublishedAt:      article.PublishedAt,
+		Content:          content,
+		Source:           source,
+		ImageCount:       inlined,
+		FailedImageCount: failed,
+		SizeBytes:        int64(len(content)),
+		ArchivedAt:       time.Now(),
+	}
+
+	if err := s.archiveRepo.Save(archive); err != nil {
+		return nil, fmt.Errorf("saving archive: %w", err)
+	}
+
+	return archive, nil
+}
+
+// inlineImages replaces the images of an HTML fragment by data URIs. Images
+// that cannot be fetched, or exceed the archive limits, keep their absolute
+// remote URL. It returns the fragment and the inlined and failed counts.
+func (s *ArchiveService) inlineImages(ctx context.Context, fragment, pageURL string) (string, int, int) {
+	if !strings.Contains(fragment, "<img") {
+		return fragment, 0, 0
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(fragment))
+	if err != nil {
+		return fragment, 0, 0
+	}
+	base, _ := url.Parse(pageURL)
+
+	// Responsive variants would point at remote files: keep one image each
+	doc.Find("picture > source").Remove()
+
+	size := int64(len(fragment))
+	inlined, failed := 0, 0
+	doc.Find("img").Each(func(_ int, sel *goquery.Selection) {
+		src, _ := sel.Attr("src")
+		if src == "" {
+			if srcset, ok := sel.Attr("srcset"); ok {
+				if fields := strings.Fields(strings.Split(srcset, ",")[0]); len(fields) > 0 {
+					src = fields[0]
+				}
+			}
+		}
+		sel.RemoveAttr("srcset")
+		sel.RemoveAttr("sizes")
+		if src == "" || strings.HasPrefix(src, "data:") {
+			return
+		}
+
+		abs := src
+		if base != nil {
+			if ref, err := base.Parse(src); err == nil {
+				abs = ref.String()
+			}
+		}
+		sel.SetAttr("src", abs)
+
+		if inlined+failed >= maxArchiveImages || ctx.Err() != nil {
+			failed++
+			return
+		}
+		img, err := s.imageProxy.Fetch(ctx, abs)
+		if err != nil {
+			failed++
+			return
+		}
+		dataURI := "data:" + img.ContentType + ";base64," + base64.StdEncoding.EncodeToString(img.Data)
+		if size+int64(len(dataURI)) > maxArchiveBytes {
+			failed++
+			return
+		}
+		size += int64(len(dataURI))
+		inlined++
+		sel.SetAttr("src", dataURI)
+	})
+
+	out, err := doc.Find("body").Html()
+	if err != nil {
+		return fragment, 0, 0
+	}
+	return out, inlined, failed
+}
@@ -82,9 +82,15 @@ type LoginResponse struct {
 
 // UserInfo contains basic user information.
 type UserInfo struct {
-	ID      uuid.UUID `json:"id"`
-	Email   string    `json:"email"`
-	IsAdmin bool      `json:"is_admin"`
+	ID          uuid.UUID `json:"id"`
+	Email       string    `json:"email"`
+	IsAdmin     bool      `json:"is_admin"`
+	ProxyImages bool      `json:"proxy_images"`
+}
+
+// PreferencesRequest contains the user-adjustable reading preferences.
+type PreferencesRequest struct {
+	ProxyImages *bool `json:"proxy_images"`
 }
 
 // Register creates a new user account.
@@ -178,9 +184,10 @@ func (s *AuthService) Login(req LoginRequest) (*LoginResponse, error) {
 		Token:     token,
 		ExpiresAt: session.ExpiresAt,
 		User: UserInfo{
-			ID:      user.ID,
-			Email:   user.Email,
-			IsAdmin: user.IsAdmin,
+			ID:          user.ID,
+			Email:       user.Email,
+			IsAdmin:     user.IsAdmin,
+			ProxyImages: user.ProxyImages,
 		},
 	}, nil
 }
@@ -208,6 +215,20 @@ func (s *AuthService) GetUserByToken(token string) (*domain.User, error) {
 	return user, nil
 }
 
+// UpdatePreferences applies the provided preference changes to a user.
+// Fields left nil in the request keep their current value.
+func (s *AuthService) UpdatePreferences(user *domain.User, req PreferencesRequest) error {
+	if req.ProxyImages != nil {
+		user.ProxyImages = *req.ProxyImages
+	}
+
+	if err := s.userRepo.UpdatePreferences(user.ID, user.ProxyImages); err != nil {
+		return fmt.Errorf("updating preferences: %w", err)
+	}
+
+	return nil
+}
+
 // hashPassword creates an Argon2id hash of the password.
 func hashPassword(password string) (string, error) {
 	salt := make([]byte, saltLength)
@@ -0,0 +1,682 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/google/uuid"
+	"github.com/michael/flowreader/internal/domain"
+	"github.com/michael/flowreader/internal/epub"
+)
+
+// EPUB service errors
+var (
+	ErrInvalidEPUBRequest = errors.New("invalid EPUB request")
+	ErrEPUBEmpty          = errors.New("no articles selected")
+	ErrEditionNotFound    = errors.New("daily edition not found")
+	ErrEditionGenerating  = errors.New("daily edition already being generated")
+	ErrEditionQueueFull   = errors.New("too many daily editions queued")
+)
+
+// EPUB limits
+const (
+	defaultEPUBArticles = 50
+	maxEPUBArticles     = 200
+
+	// maxEPUBImages and maxEPUBImageBytes bound the images embedded in one
+	// book; images past either limit are replaced by their alt text.
+	maxEPUBImages     = 300
+	maxEPUBImageBytes = 64 << 20
+
+	// editionTimeout bounds the generation of one daily edition.
+	editionTimeout = 10 * time.Minute
+
+	// editionQueueSize bounds the editions requested by users and waiting
+	// for the edition worker.
+	editionQueueSize = 16
+
+	defaultEditionHour     = 6
+	defaultEditionTimezone = "UTC"
+)
+
+// EPUBRequest selects the articles of an EPUB export. ArticleIDs is used by
+// the articles source, Category (optional) by the unread source and
+// SavedSearchID by the saved_search source. The unread and saved_search
+// sources skip read articles unless IncludeRead is set.
+type EPUBRequest struct {
+	Source        domain.EditionSource `json:"source"`
+	ArticleIDs    []uuid.UUID          `json:"article_ids,omitempty"`
+	Category      string               `json:"category,omitempty"`
+	SavedSearchID *uuid.UUID           `json:"saved_search_id,omitempty"`
+	IncludeRead   bool                 `json:"include_read,omitempty"`
+	Limit         int                  `json:"limit,omitempty"`
+	Title         string               `json:"title,omitempty"`
+}
+
+// EditionRequest holds the daily edition settings. Unset fields take their
+// defaults: enabled, 50 articles, at 6:00 UTC.
+type EditionRequest struct {
+	Enabled       *bool                `json:"enabled"`
+	Source        domain.EditionSource `json:"source"`
+	Category      string               `json:"category,omitempty"`
+	SavedSearchID *uuid.UUID           `json:"saved_search_id,omitempty"`
+	MaxArticles   int                  `json:"max_articles,omitempty"`
+	Hour          *int                 `json:"hour,omitempty"`
+	Timezone      string               `json:"timezone,omitempty"`
+}
+
+// EPUBService builds EPUB books from a selection of articles: one chapter
+// per article with its metadata, the sanitized content (the cached reader
+// view when longer than the feed's copy) and its images downloaded through
+// the SSRF-safe image proxy and embedded in the book. It also generates the
+// scheduled daily editions.
+type EPUBService struct {
+	articleRepo        domain.ArticleRepository
+	readLaterRepo      domain.ReadLaterRepository
+	editionRepo        domain.DailyEditionRepository
+	savedSearchService *SavedSearchService
+	imageProxy         *ImageProxyService
+
+	// generating holds the users whose edition is queued or being
+	// generated, so that each user has at most one in flight.
+	mu         sync.Mutex
+	generating map[uuid.UUID]bool
+	requests   chan uuid.UUID
+}
+
+// NewEPUBService creates a new EPUB service.
+func NewEPUBService(articleRepo domain.ArticleRepository, readLaterRepo domain.ReadLaterRepository, editionRepo domain.DailyEditionRepository, savedSearchService *SavedSearchService, imageProxy *ImageProxyService) *EPUBService {
+	return &EPUBService{
+		articleRepo:        articleRepo,
+		readLaterRepo:      readLaterRepo,
+		editionRepo:        editionRepo,
+		savedSearchService: savedSearchService,
+		imageProxy:         imageProxy,
+		generating:         make(map[uuid.UUID]bool),
+		requests:           make(chan uuid.UUID, editionQueueSize),
+	}
+}
+
+// Select returns the articles of an EPUB request, in reading order.
+func (s *EPUBService) Select(userID uuid.UUID, req EPUBRequest) ([]*domain.Article, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultEPUBArticles
+	}
+	if limit > maxEPUBArticles {
+		limit = maxEPUBArticles
+	}
+
+	unread := false
+	filter := domain.ArticleFilter{UserID: userID}
+	if !req.IncludeRead {
+		filter.IsRead = &unread
+	}
+
+	// order is the reading order of explicit lists
+	var order []uuid.UUID
+	switch req.Source {
+	case domain.EditionSourceArticles:
+		order = uniqueIDs(req.ArticleIDs)
+		if len(order) == 0 || len(order) > maxEPUBArticles {
+			return nil, ErrInvalidEPUBRequest
+		}
+		filter = domain.ArticleFilter{UserID: userID, IDs: order}
+		limit = len(order)
+
+	case domain.EditionSourceUnread:
+		filter.Category = strings.TrimSpace(req.Category)
+
+	case domain.EditionSourceSavedSearch:
+		if req.SavedSearchID == nil {
+			return nil, ErrInvalidEPUBRequest
+		}
+		scoped, err := s.savedSearchService.Scope(*req.SavedSearchID, userID, filter)
+		if err != nil {
+			return nil, err
+		}
+		filter = scoped
+		filter.UserID = userID
+
+	case domain.EditionSourceReadLater:
+		items, err := s.readLaterRepo.List(userID, false, limit, 0)
+		if err != nil {
+			return nil, fmt.Errorf("listing read-later queue: %w", err)
+		}
+		if len(items) == 0 {
+			return nil, ErrEPUBEmpty
+		}
+		for _, item := range items {
+			order = append(order, item.ArticleID)
+		}
+		filter = domain.ArticleFilter{UserID: userID, IDs: order}
+
+	default:
+		return nil, ErrInvalidEPUBRequest
+	}
+
+	articles, err := s.articleRepo.Query(filter, domain.Page{Limit: limit})
+	if err != nil {
+		return nil, fmt.Errorf("querying articles: %w", err)
+	}
+
+	if order != nil {
+		byID := make(map[uuid.UUID]*domain.Article, len(articles))
+		for _, a := range articles {
+			byID[a.ID] = a
+		}
+		articles = articles[:0]
+		for _, id := range order {
+			if a, ok := byID[id]; ok {
+				articles = append(articles, a)
+			}
+		}
+	}
+
+	if len(articles) == 0 {
+		return nil, ErrEPUBEmpty
+	}
+	return articles, nil
+}
+
+// Title returns the title of an EPUB request, a dated default when unset.
+func (s *EPUBService) Title(req EPUBRequest, now time.Time) string {
+	if title := strings.TrimSpace(req.Title); title != "" {
+		return title
+	}
+
+	date := now.Format("2 January 2006")
+	switch req.Source {
+	case domain.EditionSourceReadLater:
+		return "FlowReader read later, " + date
+	case domain.EditionSourceUnread:
+		if c := strings.TrimSpace(req.Category); c != "" {
+			return "FlowReader " + c + ", " + date
+		}
+	}
+	return "FlowReader, " + date
+}
+
+// Write writes an EPUB book of the articles to w.
+func (s *EPUBService) Write(ctx context.Context, w io.Writer, title string, articles []*domain.Article, now time.Time) error {
+	book, err := epub.NewWriter(w, epub.Metadata{
+		Identifier:  uuid.New(),
+		Title:       title,
+		Language:    bookLanguage(articles),
+		Creator:     "FlowReader",
+		Publisher:   "FlowReader",
+		Description: fmt.Sprintf("%d articles, %s", len(articles), now.Format("2 January 2006")),
+		Modified:    now,
+	})
+	if err != nil {
+		return err
+	}
+
+	images := &epubImages{book: book, added: make(map[string]string)}
+	for _, a := range articles {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		lang := a.Language
+		if lang == "und" {
+			lang = ""
+		}
+		chapter := epub.Chapter{
+			Title:    a.Title,
+			Language: lang,
+			Body:     s.chapterBody(ctx, a, images),
+		}
+		if err := book.AddChapter(chapter); err != nil {
+			return err
+		}
+	}
+
+	return book.Close()
+}
+
+// chapterBody returns the HTML of an article's chapter: a metadata header
+// followed by the content, with images embedded in the book.
+func (s *EPUBService) chapterBody(ctx context.Context, a *domain.Article, images *epubImages) string {
+	content := a.Content
+	if content == "" {
+		content = a.Summary
+	}
+	// Only the cached reader view: extracting here would fetch every page
+	fullText, err := s.articleRepo.GetFullText(a.ID)
+	if err != nil {
+		log.Printf("EPUB: getting full text of article %s: %v", a.ID, err)
+	} else if fullText != nil && len(fullText.Content) > len(content) {
+		content = fullText.Content
+	}
+	if a.ImageURL != "" && !strings.Contains(content, "<img") {
+		content = `<figure><img src="` + html.EscapeString(a.ImageURL) + `" alt=""/></figure>` + content
+	}
+
+	var b strings.Builder
+	b.WriteString("<h1>" + html.EscapeString(a.Title) + "</h1>")
+
+	var meta []string
+	if a.FeedTitle != "" {
+		meta = append(meta, html.EscapeString(a.FeedTitle))
+	}
+	if a.Author != "" {
+		meta = append(meta, html.EscapeString(a.Author))
+	}
+	if a.PublishedAt != nil {
+		meta = append(meta, a.PublishedAt.Format("2 January 2006"))
+	}
+	if a.URL != "" {
+		meta = append(meta, `<a href="`+html.EscapeString(a.URL)+`">Original article</a>`)
+	}
+	if len(meta) > 0 {
+		b.WriteString(`<p class="meta">` + strings.Join(meta, " · ") + "</p>")
+	}
+
+	b.WriteString(s.embedImages(ctx, content, a.URL, images))
+	return b.String()
+}
+
+// epubImages tracks the images embedded in a book, so that an image used by
+// several articles is stored once.
+type epubImages struct {
+	book  *epub.Writer
+	added map[string]string
+	count int
+	bytes int64
+}
+
+// embedImages downloads the images of an HTML fragment into the book and
+// points them at the embedded copies. Images that cannot be fetched, have a
+// type e-readers don't support or exceed the limits keep their absolute
+// remote URL, which the chapter conversion replaces by the alt text.
+func (s *EPUBService) embedImages(ctx context.Context, fragment, pageURL string, images *epubImages) string {
+	if !strings.Contains(fragment, "<img") {
+		return fragment
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(fragment))
+	if err != nil {
+		return fragment
+	}
+	base, _ := url.Parse(pageURL)
+
+	doc.Find("picture > source").Remove()
+
+	doc.Find("img").Each(func(_ int, sel *goquery.Selection) {
+		src, _ := sel.Attr("src")
+		if src == "" {
+			if srcset, ok := sel.Attr("srcset"); ok {
+				if fields := strings.Fields(strings.Split(srcset, ",")[0]); len(fields) > 0 {
+					src = fields[0]
+				}
+			}
+		}
+		sel.RemoveAttr("srcset")
+		sel.RemoveAttr("sizes")
+		if src == "" || strings.HasPrefix(src, "data:") {
+			return
+		}
+
+		// Point the image at its absolute URL first: a relative path left in
+		// place, such as ../images/x.png, would pass for an embedded image
+		abs, err := url.Parse(src)
+		if err == nil && base != nil {
+			abs, err = base.Parse(src)
+		}
+		if err != nil || abs.Host == "" || (abs.Scheme != "http" && abs.Scheme != "https") {
+			sel.RemoveAttr("src")
+			return
+		}
+		sel.SetAttr("src", abs.String())
+
+		if href, ok := images.added[abs.String()]; ok {
+			sel.SetAttr("src", href)
+			return
+		}
+
+		if images.count >= maxEPUBImages || ctx.Err() != nil {
+			return
+		}
+		img, err := s.imageProxy.Fetch(ctx, abs.String())
+		if err != nil || images.bytes+int64(len(img.Data)) > maxEPUBImageBytes {
+			return
+		}
+		href, err := images.book.AddImage(img.Data, img.ContentType)
+		if err != nil {
+			return
+		}
+
+		images.added[abs.String()] = href
+		images.count++
+		images.bytes += int64(len(img.Data))
+		sel.SetAttr("src", href)
+	})
+
+	out, err := doc.Find("body").Html()
+	if err != nil {
+		return fragment
+	}
+	return out
+}
+
+// bookLanguage returns the most common language of the articles.
+func bookLanguage(articles []*domain.Article) string {
+	counts := make(map[string]int)
+	best := ""
+	for _, a := range articles {
+		if a.Language == "" || a.Language == "und" {
+			continue
+		}
+		counts[a.Language]++
+		if counts[a.Language] > counts[best] {
+			best = a.Language
+		}
+	}
+	if best == "" {
+		return "en"
+	}
+	return best
+}
+
+// uniqueIDs returns the IDs without duplicates, in their first order.
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	out := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if id == uuid.Nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		out = append(out, id)
+	}
+	return out
+}
+
+// GetEdition returns the daily edition settings of a user.
+func (s *EPUBService) GetEdition(userID uuid.UUID) (*domain.DailyEdition, error) {
+	edition, err := s.editionRepo.Get(userID)
+	if err != nil {
+		return nil, fmt.Errorf("getting daily edition: %w", err)
+	}
+	if edition == nil {
+		return nil, ErrEditionNotFound
+	}
+
+	s.mu.Lock()
+	edition.Generating = s.generating[userID]
+	s.mu.Unlock()
+
+	return edition, nil
+}
+
+// SetEdition creates or updates the daily edition settings of a user.
+func (s *EPUBService) SetEdition(userID uuid.UUID, req EditionRequest) (*domain.DailyEdition, error) {
+	edition := &domain.DailyEdition{
+		UserID:      userID,
+		Enabled:     true,
+		Source:      req.Source,
+		Category:    strings.TrimSpace(req.Category),
+		MaxArticles: req.MaxArticles,
+		Hour:        defaultEditionHour,
+		Timezone:    strings.TrimSpace(req.Timezone),
+	}
+	if req.Enabled != nil {
+		edition.Enabled = *req.Enabled
+	}
+	if edition.Source == "" {
+		edition.Source = domain.EditionSourceUnread
+	}
+	if edition.MaxArticles == 0 {
+		edition.MaxArticles = defaultEPUBArticles
+	}
+	if req.Hour != nil {
+		edition.Hour = *req.Hour
+	}
+	if edition.Timezone == "" {
+		edition.Timezone = defaultEditionTimezone
+	}
+
+	switch edition.Source {
+	case domain.EditionSourceUnread:
+	case domain.EditionSourceReadLater:
+		edition.Category = ""
+	case domain.EditionSourceSavedSearch:
+		if req.SavedSearchID == nil {
+			return nil, ErrInvalidEPUBRequest
+		}
+		if _, err := s.savedSearchService.GetSavedSearch(*req.SavedSearchID, userID); err != nil {
+			return nil, err
+		}
+		edition.SavedSearchID = req.SavedSearchID
+		edition.Category = ""
+	default:
+		return nil, ErrInvalidEPUBRequest
+	}
+
+	if edition.MaxArticles < 1 || edition.MaxArticles > maxEPUBArticles ||
+		edition.Hour < 0 || edition.Hour > 23 || len(edition.Category) > 255 {
+		return nil, ErrInvalidEPUBRequest
+	}
+	if _, err := time.LoadLocation(edition.Timezone); err != nil || edition.Timezone == "Local" {
+		return nil, ErrInvalidEPUBRequest
+	}
+
+	if err := s.editionRepo.Save(edition); err != nil {
+		return nil, fmt.Errorf("saving daily edition: %w", err)
+	}
+
+	return s.GetEdition(userID)
+}
+
+// DeleteEdition removes the daily edition settings and file of a user.
+func (s *EPUBService) DeleteEdition(userID uuid.UUID) error {
+	deleted, err := s.editionRepo.Delete(userID)
+	if err != nil {
+		return fmt.Errorf("deleting daily edition: %w", err)
+	}
+	if !deleted {
+		return ErrEditionNotFound
+	}
+	return nil
+}
+
+// EditionFile returns the latest daily edition of a user and its settings.
+// ErrEditionNotFound is returned while the download slot is empty.
+func (s *EPUBService) EditionFile(userID uuid.UUID) ([]byte, *domain.DailyEdition, error) {
+	edition, err := s.GetEdition(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := s.editionRepo.GetFile(userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting daily edition file: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, nil, ErrEditionNotFound
+	}
+
+	return data, edition, nil
+}
+
+// claimEdition marks the edition of a user as in flight. It reports false
+// when it already is.
+func (s *EPUBService) claimEdition(userID uuid.UUID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.generating[userID] {
+		return false
+	}
+	s.generating[userID] = true
+	return true
+}
+
+func (s *EPUBService) releaseEdition(userID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.generating, userID)
+}
+
+// RequestEdition queues the generation of a user's daily edition now,
+// instead of waiting for its hour. The edition worker generates it; until
+// then the edition reports Generating.
+func (s *EPUBService) RequestEdition(userID uuid.UUID) (*domain.DailyEdition, error) {
+	edition, err := s.GetEdition(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.claimEdition(userID) {
+		return nil, ErrEditionGenerating
+	}
+
+	select {
+	case s.requests <- userID:
+	default:
+		s.releaseEdition(userID)
+		return nil, ErrEditionQueueFull
+	}
+
+	edition.Generating = true
+	return edition, nil
+}
+
+// EditionRequests returns the queue of requested editions, for the edition
+// worker to pass to GenerateRequestedEdition.
+func (s *EPUBService) EditionRequests() <-chan uuid.UUID {
+	return s.requests
+}
+
+// GenerateRequestedEdition generates an edition queued by RequestEdition,
+// replacing the content of the download slot.
+func (s *EPUBService) GenerateRequestedEdition(ctx context.Context, userID uuid.UUID) error {
+	defer s.releaseEdition(userID)
+
+	edition, err := s.GetEdition(userID)
+	if err != nil {
+		// Deleted while queued
+		if errors.Is(err, ErrEditionNotFound) {
+			return nil
+		}
+		return err
+	}
+	return s.generate(ctx, edition, time.Now())
+}
+
+// GenerateDueEditions generates the enabled daily editions due at now and
+// returns how many were generated. A failed edition is recorded and retried
+// the next day.
+func (s *EPUBService) GenerateDueEditions(ctx context.Context, now time.Time) (int, error) {
+	editions, err := s.editionRepo.ListEnabled()
+	if err != nil {
+		return 0, fmt.Errorf("listing daily editions: %w", err)
+	}
+
+	count := 0
+	for _, edition := range editions {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+		if !editionDue(edition, now) {
+			continue
+		}
+		// Requested by the user in the meantime
+		if !s.claimEdition(edition.UserID) {
+			continue
+		}
+
+		err := s.generate(ctx, edition, now)
+		s.releaseEdition(edition.UserID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return count, ctx.Err()
+			}
+			log.Printf("EPUB: daily edition of user %s failed: %v", edition.UserID, err)
+			continue
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// editionDue reports whether an edition is past its hour and was not yet
+// attempted today, in the user's time zone.
+func editionDue(edition *domain.DailyEdition, now time.Time) bool {
+	loc, err := time.LoadLocation(edition.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	local := now.In(loc)
+	if local.Hour() < edition.Hour {
+		return false
+	}
+	if edition.AttemptedAt == nil {
+		return true
+	}
+
+	y1, m1, d1 := edition.AttemptedAt.In(loc).Date()
+	y2, m2, d2 := local.Date()
+	return y1 != y2 || m1 != m2 || d1 != d2
+}
+
+// generate builds an edition and stores it in the user's download slot. An
+// empty selection clears the slot.
+func (s *EPUBService) generate(ctx context.Context, edition *domain.DailyEdition, now time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, editionTimeout)
+	defer cancel()
+
+	req := EPUBRequest{
+		Source:        edition.Source,
+		Category:      edition.Category,
+		SavedSearchID: edition.SavedSearchID,
+		Limit:         edition.MaxArticles,
+	}
+
+	articles, err := s.Select(edition.UserID, req)
+	if errors.Is(err, ErrEPUBEmpty) {
+		if err := s.editionRepo.StoreFile(edition.UserID, nil, 0, now); err != nil {
+			return fmt.Errorf("storing daily edition: %w", err)
+		}
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if err == nil {
+		err = s.Write(ctx, &buf, editionTitle(edition, now), articles, now)
+	}
+	if err != nil {
+		// Not recorded on shutdown, so that it is retried on restart
+		if !errors.Is(ctx.Err(), context.Canceled) {
+			if serr := s.editionRepo.StoreFailure(edition.UserID, err.Error(), now); serr != nil {
+				log.Printf("EPUB: recording daily edition failure: %v", serr)
+			}
+		}
+		return err
+	}
+
+	if err := s.editionRepo.StoreFile(edition.UserID, buf.Bytes(), len(articles), now); err != nil {
+		return fmt.Errorf("storing daily edition: %w", err)
+	}
+	return nil
+}
+
+// editionTitle returns the title of a daily edition, dated in the user's
+// time zone.
+func editionTitle(edition *domain.DailyEdition, now time.Time) string {
+	if loc, err := time.LoadLocation(edition.Timezone); err == nil {
+		now = now.In(loc)
+	}
+	return "FlowReader daily edition, " + now.Format("Monday 2 January 2006")
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/michael/flowreader/internal/domain"
+	"github.com/michael/flowreader/internal/epub"
+)
+
+// TestEmbedImagesUnfetched checks that images left out of the book point at
+// their absolute URL, so that the chapter conversion drops them instead of
+// taking a relative path for an embedded image.
+func TestEmbedImagesUnfetched(t *testing.T) {
+	tests := []struct {
+		name     string
+		fragment string
+		pageURL  string
+		want     string
+	}{
+		{"relative to the page", `<img src="../images/x.png" alt="X"/>`, "https://example.com/a/b/post", `src="https://example.com/a/images/x.png"`},
+		{"root relative", `<img src="/images/1.png"/>`, "https://example.com/post", `src="https://example.com/images/1.png"`},
+		{"absolute", `<img src="https://cdn.example.com/i.jpg"/>`, "https://example.com/post", `src="https://cdn.example.com/i.jpg"`},
+		{"from srcset", `<img srcset="../images/s.png 1x, ../images/l.png 2x"/>`, "https://example.com/a/post", `src="https://example.com/images/s.png"`},
+		{"no page URL", `<img src="../images/x.png" alt="X"/>`, "", `<img alt="X"/>`},
+		{"unsupported scheme", `<img src="ftp://example.com/x.png"/>`, "https://example.com/post", `<img/>`},
+	}
+
+	s := &EPUBService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The image limit is reached, so nothing is fetched
+			images := &epubImages{added: make(map[string]string), count: maxEPUBImages}
+			got := s.embedImages(context.Background(), tt.fragment, tt.pageURL, images)
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("embedImages(%s) = %s, want %s", tt.fragment, got, tt.want)
+			}
+			if strings.Contains(got, "srcset") {
+				t.Errorf("embedImages(%s) = %s, kept srcset", tt.fragment, got)
+			}
+			if xhtml := epub.XHTML(got); strings.Contains(xhtml, "<img") {
+				t.Errorf("chapter kept an image that is not in the book: %s", xhtml)
+			}
+		})
+	}
+}
+
+// memoryEditionRepo is a domain.DailyEditionRepository holding settings only.
+type memoryEditionRepo struct {
+	editions map[uuid.UUID]*domain.DailyEdition
+}
+
+func (r *memoryEditionRepo) Get(userID uuid.UUID) (*domain.DailyEdition, error) {
+	if e, ok := r.editions[userID]; ok {
+		copied := *e
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (r *memoryEditionRepo) Save(e *domain.DailyEdition) error {
+	r.editions[e.UserID] = e
+	return nil
+}
+
+func (r *memoryEditionRepo) Delete(userID uuid.UUID) (bool, error) {
+	_, ok := r.editions[userID]
+	delete(r.editions, userID)
+	return ok, nil
+}
+
+func (r *memoryEditionRepo) ListEnabled() ([]*domain.DailyEdition, error) { return nil, nil }
+func (r *memoryEditionRepo) StoreFile(uuid.UUID, []byte, int, time.Time) error {
+	return nil
+}
+func (r *memoryEditionRepo) StoreFailure(uuid.UUID, string, time.Time) error { return nil }
+func (r *memoryEditionRepo) GetFile(uuid.UUID) ([]byte, error)               { return nil, nil }
+
+func TestRequestEdition(t *testing.T) {
+	repo := &memoryEditionRepo{editions: make(map[uuid.UUID]*domain.DailyEdition)}
+	s := NewEPUBService(nil, nil, repo, nil, nil)
+
+	userID := uuid.New()
+	if _, err := s.RequestEdition(userID); !errors.Is(err, ErrEditionNotFound) {
+		t.Fatalf("RequestEdition without an edition: %v, want ErrEditionNotFound", err)
+	}
+
+	repo.Save(&domain.DailyEdition{UserID: userID})
+	edition, err := s.RequestEdition(userID)
+	if err != nil {
+		t.Fatalf("RequestEdition: %v", err)
+	}
+	if !edition.Generating {
+		t.Error("requested edition does not report generating")
+	}
+	if _, err := s.RequestEdition(userID); !errors.Is(err, ErrEditionGenerating) {
+		t.Errorf("second RequestEdition: %v, want ErrEditionGenerating", err)
+	}
+	if edition, _ := s.GetEdition(userID); !edition.Generating {
+		t.Error("GetEdition does not report the queued edition as generating")
+	}
+
+	// Fill the queue with other users' editions
+	for i := 1; i < editionQueueSize; i++ {
+		other := uuid.New()
+		repo.Save(&domain.DailyEdition{UserID: other})
+		if _, err := s.RequestEdition(other); err != nil {
+			t.Fatalf("RequestEdition %d: %v", i, err)
+		}
+	}
+	late := uuid.New()
+	repo.Save(&domain.DailyEdition{UserID: late})
+	if _, err := s.RequestEdition(late); !errors.Is(err, ErrEditionQueueFull) {
+		t.Fatalf("RequestEdition with a full queue: %v, want ErrEditionQueueFull", err)
+	}
+	if edition, _ := s.GetEdition(late); edition.Generating {
+		t.Error("rejected edition left claimed")
+	}
+
+	// Deleted while queued: the worker skips it and releases the claim
+	if got := <-s.EditionRequests(); got != userID {
+		t.Fatalf("first queued edition is %s, want %s", got, userID)
+	}
+	repo.Delete(userID)
+	if err := s.GenerateRequestedEdition(context.Background(), userID); err != nil {
+		t.Errorf("GenerateRequestedEdition of a deleted edition: %v", err)
+	}
+	repo.Save(&domain.DailyEdition{UserID: userID})
+	if edition, _ := s.GetEdition(userID); edition.Generating {
+		t.Error("edition still generating after the worker took it")
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/michael/flowreader/internal/domain"
+	"github.com/michael/flowreader/internal/export"
+	"github.com/michael/flowreader/internal/utils"
+)
+
+// exportPageSize is the number of articles loaded per query while exporting.
+const exportPageSize = 200
+
+// ExportService exports a user's articles, with their tags, AI summaries and
+// highlights, in the formats of the export package.
+type ExportService struct {
+	articleRepo   domain.ArticleRepository
+	highlightRepo domain.HighlightRepository
+	sanitizer     *utils.ContentSanitizer
+}
+
+// NewExportService creates a new export service.
+func NewExportService(articleRepo domain.ArticleRepository, highlightRepo domain.HighlightRepository, sanitizer *utils.ContentSanitizer) *ExportService {
+	return &ExportService{
+		articleRepo:   articleRepo,
+		highlightRepo: highlightRepo,
+		sanitizer:     sanitizer,
+	}
+}
+
+// sanitize cleans the HTML of an article sanitized under an older policy,
+// as the reader view does: exported HTML files embed the content as is.
+func (s *ExportService) sanitize(a *domain.Article) {
+	if a.SanitizerVersion == s.sanitizer.Version() {
+		return
+	}
+	if a.Content != "" {
+		a.Content = s.sanitizer.Sanitize(a.Content)
+	}
+	if a.Summary != "" {
+		a.Summary = s.sanitizer.Sanitize(a.Summary)
+	}
+}
+
+// ExportArticles streams the user's articles matching the filter to the
+// writer, page by page, calling flush after each page. It returns the number
+// of articles written.
+func (s *ExportService) ExportArticles(ctx context.Context, userID uuid.UUID, filter domain.ArticleFilter, w export.Writer, flush func()) (int, error) {
+	filter.UserID = userID
+	ranked := filter.Sort == domain.SortRelevance && filter.Query != ""
+
+	if err := w.Begin(); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	page := domain.Page{Limit: exportPageSize}
+	for {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+
+		articles, err := s.articleRepo.Query(filter, page)
+		if err != nil {
+			return count, fmt.Errorf("querying articles: %w", err)
+		}
+
+		ids := make([]uuid.UUID, len(articles))
+		for i, a := range articles {
+			ids[i] = a.ID
+		}
+		highlights, err := s.highlightRepo.GetByArticles(userID, ids)
+		if err != nil {
+			return count, fmt.Errorf("getting highlights: %w", err)
+		}
+
+		for _, a := range articles {
+			s.sanitize(a)
+			if err := w.Write(&export.Item{Article: a, Highlights: highlights[a.ID]}); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if flush != nil {
+			flush()
+		}
+
+		if len(articles) < page.Limit {
+			break
+		}
+		page.After = domain.CursorAfter(articles[len(articles)-1], ranked)
+	}
+
+	if err := w.End(); err != nil {
+		return count, err
+	}
+	return count, nil
+}
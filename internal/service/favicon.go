@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/michael/flowreader/internal/domain"
+	"github.com/michael/flowreader/internal/utils"
+)
+
+const (
+	// faviconRefreshInterval is how long a resolved (or failed) icon is kept
+	// before the site is checked again.
+	faviconRefreshInterval = 7 * 24 * time.Hour
+	// faviconMaxBytes caps a single icon download.
+	faviconMaxBytes = 512 << 10
+	// faviconPageMaxBytes caps how much of the site's HTML is read to find
+	// <link> tags, which live in <head>.
+	faviconPageMaxBytes = 1 << 20
+)
+
+// FaviconService resolves and stores icons for feed websites.
+type FaviconService struct {
+	iconRepo domain.FeedIconRepository
+	client   *http.Client
+}
+
+// NewFaviconService creates a new favicon service.
+func NewFaviconService(iconRepo domain.FeedIconRepository) *FaviconService {
+	return &FaviconService{
+		iconRepo: iconRepo,
+		// SSRF-hardened client: refuses to connect to private/internal addresses.
+		client: utils.SafeHTTPClient(15 * time.Second),
+	}
+}
+
+// GetIcon returns the stored icon for a feed, resolving it on first access.
+// It returns nil when the site has no usable icon.
+func (s *FaviconService) GetIcon(ctx context.Context, feed *domain.Feed) (*domain.FeedIcon, error) {
+	icon, err := s.iconRepo.Get(feed.ID)
+	if err != nil {
+		return nil, err
+	}
+	if icon == nil {
+		if icon, err = s.Refresh(ctx, feed); err != nil {
+			return nil, err
+		}
+	}
+	if len(icon.Data) == 0 {
+		return nil, nil
+	}
+	return icon, nil
+}
+
+// RefreshIfStale re-resolves the feed icon when it is missing or older than
+// the refresh interval.
+func (s *FaviconService) RefreshIfStale(ctx context.Context, feed *domain.Feed) {
+	icon, err := s.iconRepo.Get(feed.ID)
+	if err != nil {
+		log.Printf("Warning: failed to load icon for feed %s: %v", feed.ID, err)
+		return
+	}
+	if icon != nil && time.Since(icon.FetchedAt) < faviconRefreshInterval {
+		return
+	}
+	if _, err := s.Refresh(ctx, feed); err != nil {
+		log.Printf("Warning: failed to refresh icon for feed %s: %v", feed.ID, err)
+	}
+}
+
+// Refresh resolves the feed's icon and stores the result. A failed lookup is
+// stored as an empty icon so it is not retried until the next refresh window.
+func (s *FaviconService) Refresh(ctx context.Context, feed *domain.Feed) (*domain.FeedIcon, error) {
+	icon := &domain.FeedIcon{FeedID: feed.ID, FetchedAt: time.Now()}
+
+	siteURL := feed.SiteURL
+	if siteURL == "" {
+		siteURL = feed.URL
+	}
+
+	for _, candidate := range s.candidates(ctx, siteURL) {
+		img, err := s.download(ctx, candidate)
+		if err != nil {
+			continue
+		}
+		sum := sha256.Sum256(img.Data)
+		icon.Data = img.Data
+		icon.MimeType = img.ContentType
+		icon.SourceURL = candidate
+		icon.Hash = hex.EncodeToString(sum[:])
+		break
+	}
+
+	if err := s.iconRepo.Upsert(icon); err != nil {
+		return nil, fmt.Errorf("storing icon: %w", err)
+	}
+
+	return icon, nil
+}
+
+// candidates lists icon URLs for a site in order of preference: declared
+// <link rel="icon">, then apple-touch-icon, then the conventional /favicon.ico.
+func (s *FaviconService) candidates(ctx context.Context, siteURL string) []string {
+	base, err := url.Parse(siteURL)
+	if err != nil || base.Host == "" {
+		return nil
+	}
+
+	var icons, touchIcons []string
+	if page, finalURL, err := s.fetchPage(ctx, siteURL); err == nil {
+		base = finalURL
+		page.Find("link[rel][href]").Each(func(_ int, sel *goquery.Selection) {
+			href, _ := sel.Attr("href")
+			ref, err := base.Parse(strings.TrimSpace(href))
+			if err != nil {
+				return
+			}
+			for _, rel := range strings.Fields(strings.ToLower(sel.AttrOr("rel", ""))) {
+				if rel == "icon" {
+					icons = append(icons, ref.String())
+					return
+				}
+				if rel == "apple-touch-icon" || rel == "apple-touch-icon-precomposed" {
+					touchIcons = append(touchIcons, ref.String())
+					return
+				}
+			}
+		})
+	}
+
+	fallback := &url.URL{Scheme: base.Scheme, Host: base.Host, Path: "/favicon.ico"}
+
+	return append(append(icons, touchIcons...), fallback.String())
+}
+
+// fetchPage downloads and parses the site's home page.
+func (s *FaviconService) fetchPage(ctx context.Context, siteURL string) (*goquery.Document, *url.URL, error) {
+	if _, err := utils.ValidateExternalURL(siteURL); err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, siteURL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("User-Agent", "FlowReader/1.0 (RSS Reader)")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(io.LimitReader(resp.Body, faviconPageMaxBytes))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return doc, resp.Request.URL, nil
+}
+
+// download fetches a single icon candidate and checks it is an image.
+func (s *FaviconService) download(ctx context.Context, iconURL string) (*ProxiedImage, error) {
+	if strings.HasPrefix(iconURL, "data:") {
+		return nil, ErrUnsupportedImageType
+	}
+	if _, err := utils.ValidateExternalURL(iconURL); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, iconURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "FlowReader/1.0 (RSS Reader)")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, faviconMaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, ErrUnsupportedImageType
+	}
+	if len(data) > faviconMaxBytes {
+		return nil, ErrImageTooLarge
+	}
+
+	contentType := imageContentType(resp.Header.Get("Content-Type"), data)
+	if !allowedImageTypes[contentType] {
+		return nil, ErrUnsupportedImageType
+	}
+
+	return &ProxiedImage{ContentType: contentType, Data: data}, nil
+}
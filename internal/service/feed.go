@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -129,8 +130,15 @@ func (s *FeedService) DeleteFeed(feedID, userID uuid.UUID) error {
 	return nil
 }
 
-// UpdateFeed updates a feed's title.
-func (s *FeedService) UpdateFeed(feedID, userID uuid.UUID, title string) (*domain.Feed, error) {
+// UpdateFeedRequest contains the feed fields a user may change. Nil fields
+// are left untouched; an empty category removes the feed from its category.
+type UpdateFeedRequest struct {
+	Title    *string
+	Category *string
+}
+
+// UpdateFeed updates a feed's title and category.
+func (s *FeedService) UpdateFeed(feedID, userID uuid.UUID, req UpdateFeedRequest) (*domain.Feed, error) {
 	feed, err := s.feedRepo.GetByID(feedID)
 	if err != nil {
 		return nil, fmt.Errorf("getting feed: %w", err)
@@ -142,7 +150,12 @@ func (s *FeedService) UpdateFeed(feedID, userID uuid.UUID, title string) (*domai
 		return nil, ErrUnauthorized
 	}
 
-	feed.Title = title
+	if req.Title != nil {
+		feed.Title = *req.Title
+	}
+	if req.Category != nil {
+		feed.Category = strings.TrimSpace(*req.Category)
+	}
 	// UpdatedAt is handled by repo or we can set it here if we strictly follow domain logic, 
     // but repo.Update sets it to time.Now().
 
@@ -193,6 +206,7 @@ func (s *FeedService) ImportOPML(userID uuid.UUID, opmlFeeds []OPMLFeedInfo) (*I
 			URL:       opmlFeed.URL,
 			Title:     opmlFeed.Title,
 			SiteURL:   opmlFeed.SiteURL,
+			Category:  opmlFeed.Category,
 			CreatedAt: now,
 			UpdatedAt: now,
 		}
@@ -215,7 +229,8 @@ func (s *FeedService) ImportOPML(userID uuid.UUID, opmlFeeds []OPMLFeedInfo) (*I
 
 // OPMLFeedInfo contains feed info from OPML parsing.
 type OPMLFeedInfo struct {
-	URL     string
-	Title   string
-	SiteURL string
+	URL      string
+	Title    string
+	SiteURL  string
+	Category string
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/michael/flowreader/internal/domain"
 	"github.com/michael/flowreader/internal/parser"
+	"github.com/michael/flowreader/internal/utils"
 	"github.com/michael/flowreader/internal/ws"
 )
 
@@ -17,15 +18,21 @@ type FetchService struct {
 	feedRepo    domain.FeedRepository
 	articleRepo domain.ArticleRepository
 	parser      *parser.FeedParser
+	favicons    *FaviconService
+	tracking    *utils.TrackingFilter
+	sanitizer   *utils.ContentSanitizer
 	hub         *ws.Hub
 }
 
 // NewFetchService creates a new fetch service.
-func NewFetchService(feedRepo domain.FeedRepository, articleRepo domain.ArticleRepository, hub *ws.Hub) *FetchService {
+func NewFetchService(feedRepo domain.FeedRepository, articleRepo domain.ArticleRepository, favicons *FaviconService, tracking *utils.TrackingFilter, sanitizer *utils.ContentSanitizer, hub *ws.Hub) *FetchService {
 	return &FetchService{
 		feedRepo:    feedRepo,
 		articleRepo: articleRepo,
 		parser:      parser.NewFeedParser(),
+		favicons:    favicons,
+		tracking:    tracking,
+		sanitizer:   sanitizer,
 		hub:         hub,
 	}
 }
@@ -61,12 +68,28 @@ func (s *FetchService) FetchFeed(ctx context.Context, feedID uuid.UUID) error {
 		log.Printf("Warning: failed to update feed metadata: %v", err)
 	}
 
+	// Keep the site icon fresh (best-effort, only hits the network when stale)
+	if s.favicons != nil {
+		s.favicons.RefreshIfStale(ctx, feed)
+	}
+
+	// Strip tracking and sanitize before anything is stored
+	for _, article := range parsedFeed.Articles {
+		s.filterTracking(article)
+		s.sanitize(article)
+		article.Language = utils.DetectLanguage(article.Title+"\n"+article.Summary+"\n"+article.Content, article.Language, parsedFeed.Language)
+	}
+
 	// Ingest articles
 	if len(parsedFeed.Articles) > 0 {
 		if err := s.articleRepo.CreateBatch(parsedFeed.Articles); err != nil {
 			return fmt.Errorf("ingesting articles: %w", err)
 		}
 
+		if err := s.feedRepo.AddLanguages(feed.ID, articleLanguages(parsedFeed.Articles)); err != nil {
+			log.Printf("Warning: failed to record feed languages: %v", err)
+		}
+
 		// Broadcast update
 		if s.hub != nil {
 			s.hub.Broadcast("new_articles", map[string]interface{}{
@@ -83,6 +106,54 @@ func (s *FetchService) FetchFeed(ctx context.Context, feedID uuid.UUID) error {
 	return nil
 }
 
+// filterTracking canonicalizes the article URL and strips tracking from its
+// HTML. When the GUID fell back to the link, it follows the canonical URL so
+// that duplicate detection sees the same identity. The published GUID and URL
+// are kept to recognize articles stored before canonicalization.
+func (s *FetchService) filterTracking(article *domain.Article) {
+	if s.tracking == nil {
+		return
+	}
+
+	article.SourceGUID = article.GUID
+	article.SourceURL = article.URL
+	if article.URL != "" {
+		canonical := s.tracking.CleanURL(article.URL)
+		if article.GUID == article.URL {
+			article.GUID = canonical
+		}
+		article.URL = canonical
+	}
+	article.Content = s.tracking.CleanHTML(article.Content)
+	article.Summary = s.tracking.CleanHTML(article.Summary)
+	if article.ImageURL != "" && s.tracking.IsPixelURL(article.ImageURL) {
+		article.ImageURL = ""
+	}
+}
+
+// sanitize cleans the article HTML once at ingest so read paths can serve
+// the stored markup as is. The HTML as received is kept for later policies.
+func (s *FetchService) sanitize(article *domain.Article) {
+	article.RawContent = article.Content
+	article.RawSummary = article.Summary
+	article.Content = s.sanitizer.Sanitize(article.Content)
+	article.Summary = s.sanitizer.Sanitize(article.Summary)
+	article.SanitizerVersion = s.sanitizer.Version()
+}
+
+// articleLanguages returns the distinct languages of the articles.
+func articleLanguages(articles []*domain.Article) []string {
+	seen := make(map[string]bool)
+	var languages []string
+	for _, article := range articles {
+		if article.Language != "" && !seen[article.Language] {
+			seen[article.Language] = true
+			languages = append(languages, article.Language)
+		}
+	}
+	return languages
+}
+
 // FetchAllPending fetches all feeds that need updating.
 func (s *FetchService) FetchAllPending(ctx context.Context, concurrency int) (int, error) {
 	feeds, err := s.feedRepo.GetFeedsToFetch(100)
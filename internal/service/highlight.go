@@ -0,0 +1,196 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/michael/flowreader/internal/domain"
+)
+
+// Highlight service errors
+var (
+	ErrHighlightNotFound = errors.New("highlight not found")
+	ErrArticleNotFound   = errors.New("article not found")
+	ErrInvalidHighlight  = errors.New("invalid highlight")
+)
+
+// Limits on highlight fields
+const (
+	maxQuoteLength   = 10000
+	maxContextLength = 256
+	maxNoteLength    = 10000
+)
+
+// highlightColors are the colors the reader UI offers.
+var highlightColors = map[string]bool{
+	"yellow": true,
+	"green":  true,
+	"blue":   true,
+	"pink":   true,
+	"purple": true,
+}
+
+// HighlightService handles highlights and notes on articles.
+type HighlightService struct {
+	highlightRepo domain.HighlightRepository
+	articleRepo   domain.ArticleRepository
+	feedRepo      domain.FeedRepository
+}
+
+// NewHighlightService creates a new highlight service.
+func NewHighlightService(highlightRepo domain.HighlightRepository, articleRepo domain.ArticleRepository, feedRepo domain.FeedRepository) *HighlightService {
+	return &HighlightService{
+		highlightRepo: highlightRepo,
+		articleRepo:   articleRepo,
+		feedRepo:      feedRepo,
+	}
+}
+
+// CreateHighlightRequest contains the data needed to highlight a text range.
+type CreateHighlightRequest struct {
+	Quote       string `json:"quote"`
+	Prefix      string `json:"prefix"`
+	Suffix      string `json:"suffix"`
+	StartOffset *int   `json:"start_offset"`
+	Color       string `json:"color"`
+	Note        string `json:"note"`
+}
+
+// UpdateHighlightRequest contains the highlight fields a user may change.
+// Nil fields are left untouched.
+type UpdateHighlightRequest struct {
+	Color *string `json:"color"`
+	Note  *string `json:"note"`
+}
+
+// checkArticle verifies the article exists and belongs to the user.
+func (s *HighlightService) checkArticle(userID, articleID uuid.UUID) error {
+	article, err := s.articleRepo.GetByID(articleID)
+	if err != nil {
+		return fmt.Errorf("getting article: %w", err)
+	}
+	if article == nil {
+		return ErrArticleNotFound
+	}
+
+	feed, err := s.feedRepo.GetByID(article.FeedID)
+	if err != nil {
+		return fmt.Errorf("getting feed: %w", err)
+	}
+	if feed == nil || feed.UserID != userID {
+		return ErrUnauthorized
+	}
+
+	return nil
+}
+
+// Create highlights a text range of an article.
+func (s *HighlightService) Create(userID, articleID uuid.UUID, req CreateHighlightRequest) (*domain.Highlight, error) {
+	if strings.TrimSpace(req.Quote) == "" || len(req.Quote) > maxQuoteLength ||
+		len(req.Prefix) > maxContextLength || len(req.Suffix) > maxContextLength ||
+		len(req.Note) > maxNoteLength || (req.StartOffset != nil && *req.StartOffset < 0) {
+		return nil, ErrInvalidHighlight
+	}
+
+	color := req.Color
+	if color == "" {
+		color = "yellow"
+	}
+	if !highlightColors[color] {
+		return nil, ErrInvalidHighlight
+	}
+
+	if err := s.checkArticle(userID, articleID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	h := &domain.Highlight{
+		ID:          uuid.New(),
+		UserID:      userID,
+		ArticleID:   articleID,
+		Quote:       req.Quote,
+		Prefix:      req.Prefix,
+		Suffix:      req.Suffix,
+		StartOffset: req.StartOffset,
+		Color:       color,
+		Note:        strings.TrimSpace(req.Note),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := s.highlightRepo.Create(h); err != nil {
+		return nil, fmt.Errorf("creating highlight: %w", err)
+	}
+
+	return h, nil
+}
+
+// ListForArticle returns the user's highlights on an article.
+func (s *HighlightService) ListForArticle(userID, articleID uuid.UUID) ([]*domain.Highlight, error) {
+	if err := s.checkArticle(userID, articleID); err != nil {
+		return nil, err
+	}
+	return s.highlightRepo.GetByArticle(userID, articleID)
+}
+
+// List returns the user's highlights across all articles, newest first.
+func (s *HighlightService) List(userID uuid.UUID, limit, offset int) ([]*domain.Highlight, error) {
+	return s.highlightRepo.GetByUserID(userID, limit, offset)
+}
+
+// get returns a highlight if it belongs to the user.
+func (s *HighlightService) get(highlightID, userID uuid.UUID) (*domain.Highlight, error) {
+	h, err := s.highlightRepo.GetByID(highlightID)
+	if err != nil {
+		return nil, fmt.Errorf("getting highlight: %w", err)
+	}
+	if h == nil {
+		return nil, ErrHighlightNotFound
+	}
+	if h.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+	return h, nil
+}
+
+// Update changes a highlight's color or note.
+func (s *HighlightService) Update(highlightID, userID uuid.UUID, req UpdateHighlightRequest) (*domain.Highlight, error) {
+	h, err := s.get(highlightID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Color != nil {
+		if !highlightColors[*req.Color] {
+			return nil, ErrInvalidHighlight
+		}
+		h.Color = *req.Color
+	}
+	if req.Note != nil {
+		if len(*req.Note) > maxNoteLength {
+			return nil, ErrInvalidHighlight
+		}
+		h.Note = strings.TrimSpace(*req.Note)
+	}
+
+	if err := s.highlightRepo.Update(h); err != nil {
+		return nil, fmt.Errorf("updating highlight: %w", err)
+	}
+
+	return h, nil
+}
+
+// Delete removes a highlight.
+func (s *HighlightService) Delete(highlightID, userID uuid.UUID) error {
+	if _, err := s.get(highlightID, userID); err != nil {
+		return err
+	}
+	if err := s.highlightRepo.Delete(highlightID); err != nil {
+		return fmt.Errorf("deleting highlight: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/michael/flowreader/internal/domain"
+	"github.com/michael/flowreader/internal/utils"
+)
+
+// Image proxy errors
+var (
+	ErrImageTooLarge        = errors.New("image exceeds size limit")
+	ErrUnsupportedImageType = errors.New("unsupported image type")
+	ErrImageFetchFailed     = errors.New("image fetch failed")
+)
+
+// imageProxyPath is the public route served by handler.ProxyHandler.
+const imageProxyPath = "/api/v1/proxy/image"
+
+// allowedImageTypes lists the MIME types the proxy is willing to relay.
+var allowedImageTypes = map[string]bool{
+	"image/jpeg":               true,
+	"image/png":                true,
+	"image/gif":                true,
+	"image/webp":               true,
+	"image/avif":               true,
+	"image/svg+xml":            true,
+	"image/x-icon":             true,
+	"image/vnd.microsoft.icon": true,
+}
+
+// ProxiedImage is an image body ready to be relayed to the client.
+type ProxiedImage struct {
+	ContentType string
+	Data        []byte
+}
+
+// ImageProxyService fetches remote images on behalf of clients so that
+// third-party hosts never see the reader's IP and http:// images can be served
+// over HTTPS. Only URLs signed by this server are fetched.
+type ImageProxyService struct {
+	signer   *utils.URLSigner
+	client   *http.Client
+	cache    *utils.DiskCache
+	maxBytes int64
+}
+
+// NewImageProxyService creates a new image proxy. A nil cache disables disk
+// caching.
+func NewImageProxyService(signer *utils.URLSigner, cache *utils.DiskCache, maxBytes int64) *ImageProxyService {
+	return &ImageProxyService{
+		signer: signer,
+		// SSRF-hardened client: refuses to connect to private/internal addresses.
+		client:   utils.SafeHTTPClient(15 * time.Second),
+		cache:    cache,
+		maxBytes: maxBytes,
+	}
+}
+
+// URL returns the signed proxy URL for a remote image. Anything that is not an
+// absolute http(s) URL (data: URIs, relative paths) is returned unchanged.
+func (s *ImageProxyService) URL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return raw
+	}
+	return imageProxyPath + "?url=" + url.QueryEscape(raw) + "&sig=" + s.signer.Sign(raw)
+}
+
+// Verify reports whether sig is the signature the server issued for raw.
+func (s *ImageProxyService) Verify(raw, sig string) bool {
+	return s.signer.Verify(raw, sig)
+}
+
+// Fetch returns the image at raw, from the disk cache when possible.
+func (s *ImageProxyService) Fetch(ctx context.Context, raw string) (*ProxiedImage, error) {
+	if s.cache != nil {
+		if blob, ok := s.cache.Get(raw); ok {
+			if img, err := decodeCachedImage(blob); err == nil {
+				return img, nil
+			}
+		}
+	}
+
+	img, err := s.download(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.cache != nil {
+		if err := s.cache.Put(raw, encodeCachedImage(img)); err != nil {
+			log.Printf("Warning: failed to cache image %s: %v", raw, err)
+		}
+	}
+
+	return img, nil
+}
+
+// download fetches the remote image, enforcing the MIME allowlist and size cap.
+func (s *ImageProxyService) download(ctx context.Context, raw string) (*ProxiedImage, error) {
+	// Validate up-front (scheme + non-private host) before issuing the request.
+	if _, err := utils.ValidateExternalURL(raw); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, raw, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("User-Agent", "FlowReader/1.0 (Image Proxy)")
+	req.Header.Set("Accept", "image/avif,image/webp,image/*;q=0.8")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrImageFetchFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: status %d", ErrImageFetchFailed, resp.StatusCode)
+	}
+	if resp.ContentLength > s.maxBytes {
+		return nil, ErrImageTooLarge
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, s.maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrImageFetchFailed, err)
+	}
+	if int64(len(data)) > s.maxBytes {
+		return nil, ErrImageTooLarge
+	}
+
+	contentType := imageContentType(resp.Header.Get("Content-Type"), data)
+	if !allowedImageTypes[contentType] {
+		return nil, ErrUnsupportedImageType
+	}
+
+	return &ProxiedImage{ContentType: contentType, Data: data}, nil
+}
+
+// imageContentType normalizes the declared Content-Type, falling back to
+// content sniffing when the origin sends a generic or missing type.
+func imageContentType(declared string, data []byte) string {
+	mediaType, _, err := mime.ParseMediaType(declared)
+	if err == nil && strings.HasPrefix(mediaType, "image/") {
+		return mediaType
+	}
+	sniffed, _, _ := mime.ParseMediaType(http.DetectContentType(data))
+	return sniffed
+}
+
+// encodeCachedImage stores the content type on the first line of the blob.
+func encodeCachedImage(img *ProxiedImage) []byte {
+	blob := make([]byte, 0, len(img.ContentType)+1+len(img.Data))
+	blob = append(blob, img.ContentType...)
+	blob = append(blob, '\n')
+	return append(blob, img.Data...)
+}
+
+// decodeCachedImage is the inverse of encodeCachedImage.
+func decodeCachedImage(blob []byte) (*ProxiedImage, error) {
+	i := bytes.IndexByte(blob, '\n')
+	if i <= 0 {
+		return nil, fmt.Errorf("corrupt cache entry")
+	}
+	return &ProxiedImage{ContentType: string(blob[:i]), Data: blob[i+1:]}, nil
+}
+
+// RewriteHTML points every image source in an HTML fragment at the proxy.
+func (s *ImageProxyService) RewriteHTML(fragment string) string {
+	if fragment == "" || !strings.Contains(fragment, "<") {
+		return fragment
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(fragment))
+	if err != nil {
+		return fragment
+	}
+
+	doc.Find("img[src], picture > source[src]").Each(func(_ int, sel *goquery.Selection) {
+		if val, ok := sel.Attr("src"); ok {
+			sel.SetAttr("src", s.URL(val))
+		}
+	})
+	doc.Find("video[poster]").Each(func(_ int, sel *goquery.Selection) {
+		if val, ok := sel.Attr("poster"); ok {
+			sel.SetAttr("poster", s.URL(val))
+		}
+	})
+	doc.Find("img[srcset], source[srcset]").Each(func(_ int, sel *goquery.Selection) {
+		if val, ok := sel.Attr("srcset"); ok {
+			sel.SetAttr("srcset", s.rewriteSrcset(val))
+		}
+	})
+
+	out, err := doc.Find("body").Html()
+	if err != nil {
+		return fragment
+	}
+	return out
+}
+
+// rewriteSrcset proxies each candidate URL of a srcset attribute.
+func (s *ImageProxyService) rewriteSrcset(srcset string) string {
+	candidates := strings.Split(srcset, ",")
+	for i, candidate := range candidates {
+		fields := strings.Fields(candidate)
+		if len(fields) == 0 {
+			continue
+		}
+		fields[0] = s.URL(fields[0])
+		candidates[i] = strings.Join(fields, " ")
+	}
+	return strings.Join(candidates, ", ")
+}
+
+// RewriteArticle routes the article's lead image and inline images through
+// the proxy. It must run after sanitization so that only vetted markup is
+// rewritten.
+func (s *ImageProxyService) RewriteArticle(a *domain.Article) {
+	if a == nil {
+		return
+	}
+	if a.ImageURL != "" {
+		a.ImageURL = s.URL(a.ImageURL)
+	}
+	a.Content = s.RewriteHTML(a.Content)
+	a.Summary = s.RewriteHTML(a.Summary)
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/michael/flowreader/internal/domain"
+)
+
+// Quick switcher limits
+const (
+	DefaultQuickFindLimit = 5
+	MaxQuickFindLimit     = 20
+
+	// minQuickFindLength is the shortest query looked up: single characters
+	// match nearly everything and carry no trigram.
+	minQuickFindLength = 2
+	maxQuickFindLength = 256
+)
+
+// QuickFindService powers the command palette: it finds feeds, categories
+// and article titles matching a few typed characters, tolerating typos.
+type QuickFindService struct {
+	quickFindRepo domain.QuickFindRepository
+}
+
+// NewQuickFindService creates a new quick switcher service.
+func NewQuickFindService(quickFindRepo domain.QuickFindRepository) *QuickFindService {
+	return &QuickFindService{quickFindRepo: quickFindRepo}
+}
+
+// Find returns up to limit matches of each kind for the query.
+func (s *QuickFindService) Find(userID uuid.UUID, query string, limit int) (*domain.QuickFindResults, error) {
+	results := &domain.QuickFindResults{
+		Feeds:      []*domain.QuickFindFeed{},
+		Categories: []*domain.QuickFindCategory{},
+		Articles:   []*domain.QuickFindArticle{},
+	}
+
+	query = strings.Join(strings.Fields(query), " ")
+	if utf8.RuneCountInString(query) < minQuickFindLength {
+		return results, nil
+	}
+	if utf8.RuneCountInString(query) > maxQuickFindLength {
+		query = string([]rune(query)[:maxQuickFindLength])
+	}
+
+	if limit <= 0 {
+		limit = DefaultQuickFindLimit
+	}
+	if limit > MaxQuickFindLimit {
+		limit = MaxQuickFindLimit
+	}
+
+	feeds, err := s.quickFindRepo.FindFeeds(userID, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("finding feeds: %w", err)
+	}
+	if feeds != nil {
+		results.Feeds = feeds
+	}
+
+	categories, err := s.quickFindRepo.FindCategories(userID, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("finding categories: %w", err)
+	}
+	if categories != nil {
+		results.Categories = categories
+	}
+
+	articles, err := s.quickFindRepo.FindArticles(userID, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("finding articles: %w", err)
+	}
+	if articles != nil {
+		results.Articles = articles
+	}
+
+	return results, nil
+}
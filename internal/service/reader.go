@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/michael/flowreader/internal/domain"
+	"github.com/michael/flowreader/internal/utils"
+)
+
+// Reader service errors
+var (
+	ErrNoArticleURL = errors.New("article has no URL")
+)
+
+// ReaderService provides the full-page reader view of articles whose feeds
+// only carry an excerpt. Extracted content is sanitized once and cached per
+// article so the origin page is fetched at most once.
+type ReaderService struct {
+	articleRepo domain.ArticleRepository
+	extractor   *utils.ContentExtractor
+	sanitizer   *utils.ContentSanitizer
+}
+
+// NewReaderService creates a new reader service.
+func NewReaderService(articleRepo domain.ArticleRepository, sanitizer *utils.ContentSanitizer) *ReaderService {
+	return &ReaderService{
+		articleRepo: articleRepo,
+		extractor:   utils.NewContentExtractor(),
+		sanitizer:   sanitizer,
+	}
+}
+
+// GetFullText returns the cached full text of an article, extracting it from
+// the article's page when missing or when refresh is requested.
+func (s *ReaderService) GetFullText(ctx context.Context, article *domain.Article, refresh bool) (*domain.ArticleFullText, error) {
+	if !refresh {
+		cached, err := s.articleRepo.GetFullText(article.ID)
+		if err != nil {
+			return nil, err
+		}
+		if cached != nil {
+			cached.Text = utils.HTMLToText(cached.Content)
+			return cached, nil
+		}
+	}
+
+	if article.URL == "" {
+		return nil, ErrNoArticleURL
+	}
+
+	extracted, err := s.extractor.Extract(ctx, article.URL)
+	if err != nil {
+		return nil, fmt.Errorf("extracting content: %w", err)
+	}
+
+	fullText := &domain.ArticleFullText{
+		ArticleID:  article.ID,
+		Title:      strings.TrimSpace(extracted.Title),
+		Byline:     strings.TrimSpace(extracted.Byline),
+		SiteName:   strings.TrimSpace(extracted.SiteName),
+		LeadImage:  webURL(extracted.LeadImage),
+		Content:    s.sanitizer.Sanitize(extracted.Content),
+		RawContent: extracted.Content,
+	}
+	if err := s.articleRepo.UpdateFullText(fullText); err != nil {
+		return nil, err
+	}
+
+	fullText.Text = utils.HTMLToText(fullText.Content)
+	return fullText, nil
+}
+
+// webURL returns raw if it is an absolute http(s) URL, "" otherwise: page
+// metadata is not sanitized like the content.
+func webURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ""
+	}
+	return raw
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	_ "time/tzdata" // the production image ships without a zoneinfo database
+
+	"github.com/google/uuid"
+	"github.com/michael/flowreader/internal/domain"
+)
+
+// Reading service errors
+var (
+	ErrInvalidReadingEvent = errors.New("invalid reading event")
+	ErrInvalidTimezone     = errors.New("invalid timezone")
+)
+
+// Limits on reading statistics
+const (
+	// maxDwellSeconds caps the reported reading time, so that a tab left open
+	// overnight does not skew the average.
+	maxDwellSeconds = 4 * 60 * 60
+
+	DefaultStatsDays = 30
+	MaxStatsDays     = 366
+	topFeedsCount    = 5
+	dayLayout        = "2006-01-02"
+)
+
+// ReadingService records the reading history and computes reading statistics.
+type ReadingService struct {
+	readingRepo domain.ReadingRepository
+	articleRepo domain.ArticleRepository
+	feedRepo    domain.FeedRepository
+}
+
+// NewReadingService creates a new reading service.
+func NewReadingService(readingRepo domain.ReadingRepository, articleRepo domain.ArticleRepository, feedRepo domain.FeedRepository) *ReadingService {
+	return &ReadingService{
+		readingRepo: readingRepo,
+		articleRepo: articleRepo,
+		feedRepo:    feedRepo,
+	}
+}
+
+// RecordOpen records that the user opened an article, with the time spent on
+// it when the client reports it.
+func (s *ReadingService) RecordOpen(userID, articleID uuid.UUID, dwellSeconds *int) (*domain.ReadingEvent, error) {
+	article, err := s.articleRepo.GetByID(articleID)
+	if err != nil {
+		return nil, fmt.Errorf("getting article: %w", err)
+	}
+	if article == nil {
+		return nil, ErrArticleNotFound
+	}
+
+	feed, err := s.feedRepo.GetByID(article.FeedID)
+	if err != nil {
+		return nil, fmt.Errorf("getting feed: %w", err)
+	}
+	if feed == nil || feed.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	if dwellSeconds != nil {
+		if *dwellSeconds < 0 {
+			return nil, ErrInvalidReadingEvent
+		}
+		if *dwellSeconds > maxDwellSeconds {
+			capped := maxDwellSeconds
+			dwellSeconds = &capped
+		}
+	}
+
+	event := &domain.ReadingEvent{
+		ID:           uuid.New(),
+		UserID:       userID,
+		ArticleID:    &article.ID,
+		FeedID:       &article.FeedID,
+		Type:         domain.ReadingEventOpen,
+		DwellSeconds: dwellSeconds,
+		CreatedAt:    time.Now(),
+	}
+
+	if err := s.readingRepo.Record(event); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// Stats computes the user's reading statistics over the last days, counted
+// in calendar days of the given IANA time zone (UTC when empty).
+func (s *ReadingService) Stats(userID uuid.UUID, days int, tz string) (*domain.ReadingStats, error) {
+	if tz == "" {
+		tz = "UTC"
+	}
+	// "Local" is the server's zone to Go but unknown to PostgreSQL
+	loc, err := time.LoadLocation(tz)
+	if err != nil || tz == "Local" {
+		return nil, ErrInvalidTimezone
+	}
+
+	if days <= 0 {
+		days = DefaultStatsDays
+	}
+	if days > MaxStatsDays {
+		days = MaxStatsDays
+	}
+
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	from := today.AddDate(0, 0, -(days - 1))
+
+	daily, err := s.readingRepo.ReadsPerDay(userID, from, tz)
+	if err != nil {
+		return nil, err
+	}
+	perFeed, err := s.readingRepo.ReadsPerFeed(userID, from)
+	if err != nil {
+		return nil, err
+	}
+	perCategory, err := s.readingRepo.ReadsPerCategory(userID, from)
+	if err != nil {
+		return nil, err
+	}
+	avgDwell, timedOpens, err := s.readingRepo.AverageDwell(userID, from)
+	if err != nil {
+		return nil, err
+	}
+	readDays, err := s.readingRepo.ReadDays(userID, tz)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &domain.ReadingStats{
+		From:                  from,
+		To:                    now,
+		Timezone:              tz,
+		PerDay:                fillDays(daily, from, today),
+		PerFeed:               perFeed,
+		PerCategory:           perCategory,
+		AverageReadingSeconds: avgDwell,
+		TimedOpens:            timedOpens,
+	}
+	if stats.PerFeed == nil {
+		stats.PerFeed = []domain.FeedReadCount{}
+	}
+	if stats.PerCategory == nil {
+		stats.PerCategory = []domain.CategoryReadCount{}
+	}
+
+	for _, d := range stats.PerDay {
+		stats.TotalRead += d.Count
+	}
+
+	stats.TopFeeds = stats.PerFeed
+	if len(stats.TopFeeds) > topFeedsCount {
+		stats.TopFeeds = stats.TopFeeds[:topFeedsCount]
+	}
+
+	stats.CurrentStreak, stats.LongestStreak = readingStreaks(readDays, today)
+
+	return stats, nil
+}
+
+// fillDays returns one entry per day from first to last, with zero counts for
+// the days without reads.
+func fillDays(counts []domain.DailyReadCount, first, last time.Time) []domain.DailyReadCount {
+	byDay := make(map[string]int, len(counts))
+	for _, c := range counts {
+		byDay[c.Date] = c.Count
+	}
+
+	var days []domain.DailyReadCount
+	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+		date := d.Format(dayLayout)
+		days = append(days, domain.DailyReadCount{Date: date, Count: byDay[date]})
+	}
+
+	return days
+}
+
+// readingStreaks returns the current and longest runs of consecutive reading
+// days. days are sorted YYYY-MM-DD dates. The current streak is still alive
+// when the last reading day is today or yesterday.
+func readingStreaks(days []string, today time.Time) (current, longest int) {
+	var prev time.Time
+	run := 0
+	for _, day := range days {
+		d, err := time.Parse(dayLayout, day)
+		if err != nil {
+			continue
+		}
+		if run > 0 && d.Equal(prev.AddDate(0, 0, 1)) {
+			run++
+		} else {
+			run = 1
+		}
+		if run > longest {
+			longest = run
+		}
+		prev = d
+	}
+
+	if run == 0 {
+		return 0, longest
+	}
+
+	// The parsed days are UTC midnights; bring today to the same form
+	todayDate, _ := time.Parse(dayLayout, today.Format(dayLayout))
+	if prev.Equal(todayDate) || prev.Equal(todayDate.AddDate(0, 0, -1)) {
+		current = run
+	}
+
+	return current, longest
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/michael/flowreader/internal/domain"
+)
+
+// Retention service errors
+var (
+	ErrInvalidRetentionPolicy = errors.New("invalid retention policy")
+)
+
+// Upper bounds of retention limits
+const (
+	maxRetentionDays     = 100 * 365
+	maxRetentionArticles = 1000000
+
+	retentionDryRunTimeout = 2 * time.Minute
+)
+
+// RetentionService manages the retention policies enforced by the cleaner.
+type RetentionService struct {
+	retentionRepo domain.RetentionRepository
+	feedRepo      domain.FeedRepository
+}
+
+// NewRetentionService creates a new retention service.
+func NewRetentionService(retentionRepo domain.RetentionRepository, feedRepo domain.FeedRepository) *RetentionService {
+	return &RetentionService{
+		retentionRepo: retentionRepo,
+		feedRepo:      feedRepo,
+	}
+}
+
+// RetentionRequest sets the limits of a retention policy. Omitted limits are
+// removed: a policy without limits never deletes articles.
+type RetentionRequest struct {
+	MaxAgeDays  *int `json:"max_age_days"`
+	MaxArticles *int `json:"max_articles"`
+	KeepUnread  bool `json:"keep_unread"`
+}
+
+// RetentionSettings are the retention policies that apply to a user's feeds.
+// User and feed policies are absent when they are inherited.
+type RetentionSettings struct {
+	Instance *domain.RetentionPolicy   `json:"instance"`
+	User     *domain.RetentionPolicy   `json:"user"`
+	Feeds    []*domain.RetentionPolicy `json:"feeds"`
+}
+
+func (req RetentionRequest) validate() error {
+	if req.MaxAgeDays != nil && (*req.MaxAgeDays < 1 || *req.MaxAgeDays > maxRetentionDays) {
+		return ErrInvalidRetentionPolicy
+	}
+	if req.MaxArticles != nil && (*req.MaxArticles < 1 || *req.MaxArticles > maxRetentionArticles) {
+		return ErrInvalidRetentionPolicy
+	}
+	return nil
+}
+
+// GetSettings returns the policies that apply to the user's feeds.
+func (s *RetentionService) GetSettings(userID uuid.UUID) (*RetentionSettings, error) {
+	instance, err := s.retentionRepo.GetInstance()
+	if err != nil {
+		return nil, fmt.Errorf("getting instance retention policy: %w", err)
+	}
+
+	user, err := s.retentionRepo.GetForUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("getting user retention policy: %w", err)
+	}
+
+	feeds, err := s.retentionRepo.ListFeedPolicies(userID)
+	if err != nil {
+		return nil, fmt.Errorf("getting feed retention policies: %w", err)
+	}
+	if feeds == nil {
+		feeds = []*domain.RetentionPolicy{}
+	}
+
+	return &RetentionSettings{Instance: instance, User: user, Feeds: feeds}, nil
+}
+
+// save creates or updates a policy with the request's limits.
+func (s *RetentionService) save(existing *domain.RetentionPolicy, policy *domain.RetentionPolicy, req RetentionRequest) (*domain.RetentionPolicy, error) {
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if existing != nil {
+		policy = existing
+	} else {
+		policy.ID = uuid.New()
+		policy.CreatedAt = now
+	}
+	policy.MaxAgeDays = req.MaxAgeDays
+	policy.MaxArticles = req.MaxArticles
+	policy.KeepUnread = req.KeepUnread
+	policy.UpdatedAt = now
+
+	if existing != nil {
+		if err := s.retentionRepo.Update(policy); err != nil {
+			return nil, fmt.Errorf("updating retention policy: %w", err)
+		}
+		return policy, nil
+	}
+	if err := s.retentionRepo.Create(policy); err != nil {
+		return nil, fmt.Errorf("creating retention policy: %w", err)
+	}
+	return policy, nil
+}
+
+// GetInstancePolicy returns the instance-wide default policy, nil when there
+// is none.
+func (s *RetentionService) GetInstancePolicy() (*domain.RetentionPolicy, error) {
+	policy, err := s.retentionRepo.GetInstance()
+	if err != nil {
+		return nil, fmt.Errorf("getting instance retention policy: %w", err)
+	}
+	return policy, nil
+}
+
+// SetInstancePolicy sets the instance-wide default policy.
+func (s *RetentionService) SetInstancePolicy(req RetentionRequest) (*domain.RetentionPolicy, error) {
+	existing, err := s.retentionRepo.GetInstance()
+	if err != nil {
+		return nil, fmt.Errorf("getting instance retention policy: %w", err)
+	}
+	return s.save(existing, &domain.RetentionPolicy{Scope: domain.RetentionScopeInstance}, req)
+}
+
+// SetUserPolicy sets the policy of all of the user's feeds that have no
+// policy of their own.
+func (s *RetentionService) SetUserPolicy(userID uuid.UUID, req RetentionRequest) (*domain.RetentionPolicy, error) {
+	existing, err := s.retentionRepo.GetForUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("getting user retention policy: %w", err)
+	}
+	return s.save(existing, &domain.RetentionPolicy{
+		Scope:  domain.RetentionScopeUser,
+		UserID: &userID,
+	}, req)
+}
+
+// DeleteUserPolicy removes the user's policy, so that the instance policy
+// applies again.
+func (s *RetentionService) DeleteUserPolicy(userID uuid.UUID) error {
+	existing, err := s.retentionRepo.GetForUser(userID)
+	if err != nil {
+		return fmt.Errorf("getting user retention policy: %w", err)
+	}
+	if existing == nil {
+		return nil
+	}
+	if err := s.retentionRepo.Delete(existing.ID); err != nil {
+		return fmt.Errorf("deleting retention policy: %w", err)
+	}
+	return nil
+}
+
+// checkFeedOwner verifies that the feed exists and belongs to the user.
+func (s *RetentionService) checkFeedOwner(feedID, userID uuid.UUID) error {
+	feed, err := s.feedRepo.GetByID(feedID)
+	if err != nil {
+		return fmt.Errorf("getting feed: %w", err)
+	}
+	if feed == nil {
+		return ErrFeedNotFound
+	}
+	if feed.UserID != userID {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// GetFeedPolicy returns the policy of a feed, nil when it is inherited.
+func (s *RetentionService) GetFeedPolicy(feedID, userID uuid.UUID) (*domain.RetentionPolicy, error) {
+	if err := s.checkFeedOwner(feedID, userID); err != nil {
+		return nil, err
+	}
+	policy, err := s.retentionRepo.GetForFeed(feedID)
+	if err != nil {
+		return nil, fmt.Errorf("getting feed retention policy: %w", err)
+	}
+	return policy, nil
+}
+
+// SetFeedPolicy sets the policy of one of the user's feeds.
+func (s *RetentionService) SetFeedPolicy(feedID, userID uuid.UUID, req RetentionRequest) (*domain.RetentionPolicy, error) {
+	existing, err := s.GetFeedPolicy(feedID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.save(existing, &domain.RetentionPolicy{
+		Scope:  domain.RetentionScopeFeed,
+		UserID: &userID,
+		FeedID: &feedID,
+	}, req)
+}
+
+// DeleteFeedPolicy removes a feed's policy, so that the user or instance
+// policy applies again.
+func (s *RetentionService) DeleteFeedPolicy(feedID, userID uuid.UUID) error {
+	existing, err := s.GetFeedPolicy(feedID, userID)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return nil
+	}
+	if err := s.retentionRepo.Delete(existing.ID); err != nil {
+		return fmt.Errorf("deleting retention policy: %w", err)
+	}
+	return nil
+}
+
+// DryRun reports how many articles each policy in effect would delete on
+// the next cleanup. With uuid.Nil every user's feeds are considered.
+func (s *RetentionService) DryRun(userID uuid.UUID) ([]*domain.RetentionPurgeCount, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), retentionDryRunTimeout)
+	defer cancel()
+
+	counts, err := s.retentionRepo.PurgeCounts(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("counting retention candidates: %w", err)
+	}
+	if counts == nil {
+		counts = []*domain.RetentionPurgeCount{}
+	}
+	return counts, nil
+}
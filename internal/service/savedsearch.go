@@ -0,0 +1,321 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/michael/flowreader/internal/domain"
+	"github.com/michael/flowreader/internal/search"
+)
+
+// Saved search service errors
+var (
+	ErrSavedSearchNotFound = errors.New("saved search not found")
+	ErrSavedSearchExists   = errors.New("saved search already exists")
+	ErrInvalidSavedSearch  = errors.New("invalid saved search")
+)
+
+// maxSavedSearchNameLength is the maximum saved search name length in characters.
+const maxSavedSearchNameLength = 128
+
+// SavedSearchService handles saved searches (smart folders). Besides the
+// HTTP API it resolves a saved search into an article filter, so anything
+// that works on a scope of articles can target a smart folder.
+type SavedSearchService struct {
+	savedSearchRepo domain.SavedSearchRepository
+	articleRepo     domain.ArticleRepository
+}
+
+// NewSavedSearchService creates a new saved search service.
+func NewSavedSearchService(savedSearchRepo domain.SavedSearchRepository, articleRepo domain.ArticleRepository) *SavedSearchService {
+	return &SavedSearchService{
+		savedSearchRepo: savedSearchRepo,
+		articleRepo:     articleRepo,
+	}
+}
+
+// SavedSearchRequest contains the saved search fields a user may set. Nil
+// fields are left untouched on update.
+type SavedSearchRequest struct {
+	Name     *string                    `json:"name"`
+	Query    *string                    `json:"query"`
+	Filters  *domain.SavedSearchFilters `json:"filters"`
+	Position *int                       `json:"position"`
+}
+
+// normalizeSavedSearchName trims a saved search name and validates its length.
+func normalizeSavedSearchName(name string) (string, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" || utf8.RuneCountInString(name) > maxSavedSearchNameLength {
+		return "", ErrInvalidSavedSearch
+	}
+	return name, nil
+}
+
+// normalizeSavedSearchFilters cleans up the stored filters.
+func normalizeSavedSearchFilters(filters domain.SavedSearchFilters) (domain.SavedSearchFilters, error) {
+	if filters.MaxAgeDays < 0 {
+		return filters, ErrInvalidSavedSearch
+	}
+	filters.Category = strings.TrimSpace(filters.Category)
+	filters.Author = strings.TrimSpace(filters.Author)
+
+	var tags []string
+	for _, tag := range filters.Tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	filters.Tags = tags
+
+	return filters, nil
+}
+
+// parseSavedSearchQuery validates a saved search query.
+func parseSavedSearchQuery(query string) (*search.Query, error) {
+	parsed, err := search.Parse(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSavedSearch, err)
+	}
+	return parsed, nil
+}
+
+// savedSearchFilter returns the article filter selecting a saved search's
+// articles at the given time. The query goes through the search syntax, so
+// operators such as is:unread or tag: work as they do in a search.
+func savedSearchFilter(saved *domain.SavedSearch, now time.Time) domain.ArticleFilter {
+	filter := domain.ArticleFilter{
+		UserID:     saved.UserID,
+		FeedIDs:    saved.Filters.FeedIDs,
+		Category:   saved.Filters.Category,
+		Tags:       saved.Filters.Tags,
+		Author:     saved.Filters.Author,
+		IsFavorite: saved.Filters.IsFavorite,
+		HasImage:   saved.Filters.HasImage,
+		Sort:       domain.SortNewest,
+	}
+
+	if parsed, err := parseSavedSearchQuery(saved.Query); err == nil {
+		parsed.Apply(&filter)
+	} else {
+		// Saved before queries were validated: search it as plain text
+		filter.Query = saved.Query
+	}
+
+	if saved.Filters.MaxAgeDays > 0 {
+		after := now.AddDate(0, 0, -saved.Filters.MaxAgeDays)
+		if filter.PublishedAfter == nil || after.After(*filter.PublishedAfter) {
+			filter.PublishedAfter = &after
+		}
+	}
+	if filter.Query != "" {
+		filter.Sort = domain.SortRelevance
+	}
+	return filter
+}
+
+// ListSavedSearches returns the user's saved searches with their unread counts.
+func (s *SavedSearchService) ListSavedSearches(userID uuid.UUID) ([]*domain.SavedSearch, error) {
+	searches, err := s.savedSearchRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("getting saved searches: %w", err)
+	}
+
+	if err := s.fillUnreadCounts(userID, searches); err != nil {
+		return nil, err
+	}
+
+	return searches, nil
+}
+
+// UnreadCounts returns the unread count of each of the user's saved searches.
+func (s *SavedSearchService) UnreadCounts(userID uuid.UUID) (map[uuid.UUID]int, error) {
+	searches, err := s.ListSavedSearches(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]int, len(searches))
+	for _, search := range searches {
+		counts[search.ID] = search.UnreadCount
+	}
+	return counts, nil
+}
+
+// fillUnreadCounts sets the unread count of the saved searches, counting all
+// of them in one query.
+func (s *SavedSearchService) fillUnreadCounts(userID uuid.UUID, searches []*domain.SavedSearch) error {
+	if len(searches) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	filters := make([]domain.ArticleFilter, len(searches))
+	for i, search := range searches {
+		filters[i] = savedSearchFilter(search, now)
+	}
+
+	counts, err := s.articleRepo.CountUnreadMatching(userID, filters)
+	if err != nil {
+		return fmt.Errorf("counting saved search unread articles: %w", err)
+	}
+	for i, search := range searches {
+		search.UnreadCount = counts[i]
+	}
+
+	return nil
+}
+
+// CreateSavedSearch creates a new saved search for the user.
+func (s *SavedSearchService) CreateSavedSearch(userID uuid.UUID, req SavedSearchRequest) (*domain.SavedSearch, error) {
+	if req.Name == nil {
+		return nil, ErrInvalidSavedSearch
+	}
+	name, err := normalizeSavedSearchName(*req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	search := &domain.SavedSearch{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if req.Query != nil {
+		query := strings.TrimSpace(*req.Query)
+		if _, err := parseSavedSearchQuery(query); err != nil {
+			return nil, err
+		}
+		search.Query = query
+	}
+	if req.Filters != nil {
+		if search.Filters, err = normalizeSavedSearchFilters(*req.Filters); err != nil {
+			return nil, err
+		}
+	}
+	if req.Position != nil {
+		search.Position = *req.Position
+	}
+
+	existing, err := s.savedSearchRepo.GetByName(userID, name)
+	if err != nil {
+		return nil, fmt.Errorf("checking saved search: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrSavedSearchExists
+	}
+
+	if err := s.savedSearchRepo.Create(search); err != nil {
+		return nil, fmt.Errorf("creating saved search: %w", err)
+	}
+
+	if err := s.fillUnreadCounts(userID, []*domain.SavedSearch{search}); err != nil {
+		return nil, err
+	}
+
+	return search, nil
+}
+
+// GetSavedSearch returns a saved search if it belongs to the user.
+func (s *SavedSearchService) GetSavedSearch(searchID, userID uuid.UUID) (*domain.SavedSearch, error) {
+	search, err := s.savedSearchRepo.GetByID(searchID)
+	if err != nil {
+		return nil, fmt.Errorf("getting saved search: %w", err)
+	}
+	if search == nil {
+		return nil, ErrSavedSearchNotFound
+	}
+	if search.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+	return search, nil
+}
+
+// UpdateSavedSearch changes a saved search.
+func (s *SavedSearchService) UpdateSavedSearch(searchID, userID uuid.UUID, req SavedSearchRequest) (*domain.SavedSearch, error) {
+	search, err := s.GetSavedSearch(searchID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name, err := normalizeSavedSearchName(*req.Name)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(name, search.Name) {
+			existing, err := s.savedSearchRepo.GetByName(userID, name)
+			if err != nil {
+				return nil, fmt.Errorf("checking saved search: %w", err)
+			}
+			if existing != nil {
+				return nil, ErrSavedSearchExists
+			}
+		}
+		search.Name = name
+	}
+	if req.Query != nil {
+		query := strings.TrimSpace(*req.Query)
+		if _, err := parseSavedSearchQuery(query); err != nil {
+			return nil, err
+		}
+		search.Query = query
+	}
+	if req.Filters != nil {
+		if search.Filters, err = normalizeSavedSearchFilters(*req.Filters); err != nil {
+			return nil, err
+		}
+	}
+	if req.Position != nil {
+		search.Position = *req.Position
+	}
+	search.UpdatedAt = time.Now()
+
+	if err := s.savedSearchRepo.Update(search); err != nil {
+		return nil, fmt.Errorf("updating saved search: %w", err)
+	}
+
+	if err := s.fillUnreadCounts(userID, []*domain.SavedSearch{search}); err != nil {
+		return nil, err
+	}
+
+	return search, nil
+}
+
+// DeleteSavedSearch deletes a saved search.
+func (s *SavedSearchService) DeleteSavedSearch(searchID, userID uuid.UUID) error {
+	if _, err := s.GetSavedSearch(searchID, userID); err != nil {
+		return err
+	}
+	if err := s.savedSearchRepo.Delete(searchID); err != nil {
+		return fmt.Errorf("deleting saved search: %w", err)
+	}
+	return nil
+}
+
+// Scope returns the article filter of a saved search, narrowed by the read
+// state, ordering and high-water marks of the given request filter.
+func (s *SavedSearchService) Scope(searchID, userID uuid.UUID, req domain.ArticleFilter) (domain.ArticleFilter, error) {
+	search, err := s.GetSavedSearch(searchID, userID)
+	if err != nil {
+		return req, err
+	}
+
+	filter := savedSearchFilter(search, time.Now())
+	if req.IsRead != nil {
+		filter.IsRead = req.IsRead
+	}
+	filter.CreatedBefore = req.CreatedBefore
+	if req.Sort != "" && (req.Sort != domain.SortRelevance || filter.Query != "") {
+		filter.Sort = req.Sort
+	}
+
+	return filter, nil
+}
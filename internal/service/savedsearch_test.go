@@ -0,0 +1,89 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/michael/flowreader/internal/domain"
+	"github.com/michael/flowreader/internal/search"
+)
+
+func TestSavedSearchFilter(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+	yes, no := true, false
+	weekAgo := now.AddDate(0, 0, -7)
+	march := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		query   string
+		filters domain.SavedSearchFilters
+		want    domain.ArticleFilter
+	}{
+		{
+			name:    "filters only",
+			filters: domain.SavedSearchFilters{Tags: []string{"go"}, IsFavorite: &yes, MaxAgeDays: 7},
+			want:    domain.ArticleFilter{UserID: userID, Tags: []string{"go"}, IsFavorite: &yes, PublishedAfter: &weekAgo, Sort: domain.SortNewest},
+		},
+		{
+			name:  "operators applied",
+			query: `rust is:unread -feed:"Hacker News" tag:web`,
+			want: domain.ArticleFilter{
+				UserID: userID, Query: "rust", IsRead: &no, ExcludeFeedTitles: []string{"Hacker News"},
+				Tags: []string{"web"}, Sort: domain.SortRelevance,
+			},
+		},
+		{
+			name:    "operators only keep newest first",
+			query:   "is:starred",
+			filters: domain.SavedSearchFilters{Tags: []string{"go"}},
+			want:    domain.ArticleFilter{UserID: userID, Tags: []string{"go"}, IsFavorite: &yes, Sort: domain.SortNewest},
+		},
+		{
+			name:    "later after: narrows max age",
+			query:   "after:2024-03-10",
+			filters: domain.SavedSearchFilters{MaxAgeDays: 30},
+			want:    domain.ArticleFilter{UserID: userID, PublishedAfter: &march, Sort: domain.SortNewest},
+		},
+		{
+			name:    "max age narrows earlier after:",
+			query:   "after:2024-01-01",
+			filters: domain.SavedSearchFilters{MaxAgeDays: 7},
+			want:    domain.ArticleFilter{UserID: userID, PublishedAfter: &weekAgo, Sort: domain.SortNewest},
+		},
+		{
+			name:  "invalid stored query searched as text",
+			query: "is:maybe",
+			want:  domain.ArticleFilter{UserID: userID, Query: "is:maybe", Sort: domain.SortRelevance},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saved := &domain.SavedSearch{UserID: userID, Query: tt.query, Filters: tt.filters}
+			got := savedSearchFilter(saved, now)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("savedSearchFilter(%q)\n got %+v\nwant %+v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSavedSearchQuery(t *testing.T) {
+	_, err := parseSavedSearchQuery("is:read is:unread")
+	if !errors.Is(err, ErrInvalidSavedSearch) {
+		t.Errorf("error %v is not ErrInvalidSavedSearch", err)
+	}
+	var perr *search.Error
+	if !errors.As(err, &perr) || perr.Code != search.ErrConflict {
+		t.Errorf("error %v does not carry the syntax error", err)
+	}
+
+	if _, err := parseSavedSearchQuery("rust re:Invent"); err != nil {
+		t.Errorf("valid query rejected: %v", err)
+	}
+}
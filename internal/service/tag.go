@@ -0,0 +1,268 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/michael/flowreader/internal/domain"
+)
+
+// Tag service errors
+var (
+	ErrTagNotFound    = errors.New("tag not found")
+	ErrTagExists      = domain.ErrTagExists
+	ErrInvalidTagName = errors.New("invalid tag name")
+	ErrInvalidColor   = errors.New("invalid tag color")
+)
+
+// maxTagNameLength is the maximum tag name length in characters.
+const maxTagNameLength = 64
+
+var tagColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// TagService handles user-defined article tags. Besides the HTTP API it is
+// the entry point for anything that tags articles automatically (ingest
+// rules, AI suggestions).
+type TagService struct {
+	tagRepo domain.TagRepository
+}
+
+// NewTagService creates a new tag service.
+func NewTagService(tagRepo domain.TagRepository) *TagService {
+	return &TagService{tagRepo: tagRepo}
+}
+
+// TagRequest contains the tag fields a user may set. Nil fields are left
+// untouched on update.
+type TagRequest struct {
+	Name  *string `json:"name"`
+	Color *string `json:"color"`
+}
+
+// normalizeTagName trims a tag name and validates its length.
+func normalizeTagName(name string) (string, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" || utf8.RuneCountInString(name) > maxTagNameLength {
+		return "", ErrInvalidTagName
+	}
+	return name, nil
+}
+
+// ListTags returns the user's tags with their article counts.
+func (s *TagService) ListTags(userID uuid.UUID) ([]*domain.Tag, error) {
+	tags, err := s.tagRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("getting tags: %w", err)
+	}
+	return tags, nil
+}
+
+// CreateTag creates a new tag for the user.
+func (s *TagService) CreateTag(userID uuid.UUID, req TagRequest) (*domain.Tag, error) {
+	if req.Name == nil {
+		return nil, ErrInvalidTagName
+	}
+	name, err := normalizeTagName(*req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	tag := &domain.Tag{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      name,
+		CreatedAt: time.Now(),
+	}
+	if req.Color != nil && *req.Color != "" {
+		if !tagColorPattern.MatchString(*req.Color) {
+			return nil, ErrInvalidColor
+		}
+		tag.Color = *req.Color
+	}
+
+	created, err := s.tagRepo.Create(tag)
+	if err != nil {
+		return nil, fmt.Errorf("creating tag: %w", err)
+	}
+	if !created {
+		return nil, ErrTagExists
+	}
+
+	return tag, nil
+}
+
+// GetTag returns a tag if it belongs to the user.
+func (s *TagService) GetTag(tagID, userID uuid.UUID) (*domain.Tag, error) {
+	tag, err := s.tagRepo.GetByID(tagID)
+	if err != nil {
+		return nil, fmt.Errorf("getting tag: %w", err)
+	}
+	if tag == nil {
+		return nil, ErrTagNotFound
+	}
+	if tag.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+	return tag, nil
+}
+
+// UpdateTag renames or recolors a tag.
+func (s *TagService) UpdateTag(tagID, userID uuid.UUID, req TagRequest) (*domain.Tag, error) {
+	tag, err := s.GetTag(tagID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name, err := normalizeTagName(*req.Name)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(name, tag.Name) {
+			existing, err := s.tagRepo.GetByNames(userID, []string{name})
+			if err != nil {
+				return nil, fmt.Errorf("checking tag: %w", err)
+			}
+			if len(existing) > 0 {
+				return nil, ErrTagExists
+			}
+		}
+		tag.Name = name
+	}
+	if req.Color != nil {
+		if *req.Color != "" && !tagColorPattern.MatchString(*req.Color) {
+			return nil, ErrInvalidColor
+		}
+		tag.Color = *req.Color
+	}
+
+	if err := s.tagRepo.Update(tag); err != nil {
+		if errors.Is(err, ErrTagExists) {
+			// Renamed concurrently to the same name
+			return nil, ErrTagExists
+		}
+		return nil, fmt.Errorf("updating tag: %w", err)
+	}
+
+	return tag, nil
+}
+
+// DeleteTag deletes a tag and removes it from all articles.
+func (s *TagService) DeleteTag(tagID, userID uuid.UUID) error {
+	if _, err := s.GetTag(tagID, userID); err != nil {
+		return err
+	}
+	if err := s.tagRepo.Delete(tagID); err != nil {
+		return fmt.Errorf("deleting tag: %w", err)
+	}
+	return nil
+}
+
+// ResolveTags returns the user's tags with the given names, creating the
+// missing ones.
+func (s *TagService) ResolveTags(userID uuid.UUID, names []string) ([]*domain.Tag, error) {
+	seen := make(map[string]bool)
+	var wanted []string
+	for _, raw := range names {
+		name, err := normalizeTagName(raw)
+		if err != nil {
+			return nil, err
+		}
+		if key := strings.ToLower(name); !seen[key] {
+			seen[key] = true
+			wanted = append(wanted, name)
+		}
+	}
+	if len(wanted) == 0 {
+		return nil, nil
+	}
+
+	existing, err := s.tagRepo.GetByNames(userID, wanted)
+	if err != nil {
+		return nil, fmt.Errorf("getting tags: %w", err)
+	}
+	found := make(map[string]bool, len(existing))
+	for _, tag := range existing {
+		found[strings.ToLower(tag.Name)] = true
+	}
+
+	tags := existing
+	for _, name := range wanted {
+		if found[strings.ToLower(name)] {
+			continue
+		}
+		tag := &domain.Tag{
+			ID:        uuid.New(),
+			UserID:    userID,
+			Name:      name,
+			CreatedAt: time.Now(),
+		}
+		created, err := s.tagRepo.Create(tag)
+		if err != nil {
+			return nil, fmt.Errorf("creating tag: %w", err)
+		}
+		if !created {
+			// Created concurrently, by another request or an ingest rule
+			raced, err := s.tagRepo.GetByNames(userID, []string{name})
+			if err != nil {
+				return nil, fmt.Errorf("getting tags: %w", err)
+			}
+			if len(raced) == 0 {
+				return nil, fmt.Errorf("creating tag %q: conflicting tag not found", name)
+			}
+			tag = raced[0]
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// TagArticles applies the named tags (created as needed) to the user's
+// articles and returns the IDs of the articles that were found.
+func (s *TagService) TagArticles(userID uuid.UUID, articleIDs []uuid.UUID, names []string) ([]uuid.UUID, error) {
+	tags, err := s.ResolveTags(userID, names)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) == 0 {
+		return nil, ErrInvalidTagName
+	}
+	return s.tagRepo.AddToArticles(userID, tagIDs(tags), articleIDs)
+}
+
+// UntagArticles removes the named tags from the user's articles and returns
+// the IDs of the articles that were found.
+func (s *TagService) UntagArticles(userID uuid.UUID, articleIDs []uuid.UUID, names []string) ([]uuid.UUID, error) {
+	tags, err := s.tagRepo.GetByNames(userID, names)
+	if err != nil {
+		return nil, fmt.Errorf("getting tags: %w", err)
+	}
+	return s.tagRepo.RemoveFromArticles(userID, tagIDs(tags), articleIDs)
+}
+
+// SetArticleTags replaces the tags of an article the caller has verified
+// the user owns.
+func (s *TagService) SetArticleTags(userID, articleID uuid.UUID, names []string) ([]*domain.Tag, error) {
+	tags, err := s.ResolveTags(userID, names)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tagRepo.SetForArticle(articleID, tagIDs(tags)); err != nil {
+		return nil, fmt.Errorf("setting article tags: %w", err)
+	}
+	return tags, nil
+}
+
+func tagIDs(tags []*domain.Tag) []uuid.UUID {
+	ids := make([]uuid.UUID, len(tags))
+	for i, tag := range tags {
+		ids[i] = tag.ID
+	}
+	return ids
+}
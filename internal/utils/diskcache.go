@@ -0,0 +1,200 @@
+package utils
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DiskCache is a size-bounded, least-recently-used blob cache backed by a
+// directory. Keys are hashed into file names; the LRU index lives in memory
+// and is rebuilt from file modification times on startup.
+type DiskCache struct {
+	dir      string
+	maxBytes int64
+
+	mu    sync.Mutex
+	size  int64
+	lru   *list.List // front = most recently used
+	items map[string]*list.Element
+}
+
+type diskCacheEntry struct {
+	name string
+	size int64
+}
+
+// NewDiskCache opens (or creates) a cache directory bounded to maxBytes.
+func NewDiskCache(dir string, maxBytes int64) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating cache dir: %w", err)
+	}
+
+	c := &DiskCache{
+		dir:      dir,
+		maxBytes: maxBytes,
+		lru:      list.New(),
+		items:    make(map[string]*list.Element),
+	}
+
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// load indexes existing files, oldest first, so that eviction order survives
+// restarts.
+func (c *DiskCache) load() error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("reading cache dir: %w", err)
+	}
+
+	type fileInfo struct {
+		name    string
+		size    int64
+		modTime time.Time
+	}
+	var files []fileInfo
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if filepath.Ext(entry.Name()) == ".tmp" {
+			// Leftover from an interrupted write.
+			os.Remove(filepath.Join(c.dir, entry.Name()))
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, fileInfo{name: entry.Name(), size: info.Size(), modTime: info.ModTime()})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, f := range files {
+		c.items[f.name] = c.lru.PushFront(&diskCacheEntry{name: f.name, size: f.size})
+		c.size += f.size
+	}
+	c.evictLocked()
+
+	return nil
+}
+
+// Get returns the cached blob for key, marking it as recently used.
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	name := cacheFileName(key)
+
+	c.mu.Lock()
+	elem, ok := c.items[name]
+	if ok {
+		c.lru.MoveToFront(elem)
+	}
+	c.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(filepath.Join(c.dir, name))
+	if err != nil {
+		c.remove(name)
+		return nil, false
+	}
+
+	// Touch the file so the on-disk order matches the in-memory one.
+	now := time.Now()
+	_ = os.Chtimes(filepath.Join(c.dir, name), now, now)
+
+	return data, true
+}
+
+// Put stores data under key, evicting least-recently-used entries as needed.
+// Blobs larger than the whole cache are silently not stored.
+func (c *DiskCache) Put(key string, data []byte) error {
+	size := int64(len(data))
+	if size > c.maxBytes {
+		return nil
+	}
+
+	name := cacheFileName(key)
+	path := filepath.Join(c.dir, name)
+
+	// Write atomically so concurrent readers never see a partial file.
+	tmp, err := os.CreateTemp(c.dir, name+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating cache file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing cache file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("closing cache file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("renaming cache file: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.items[name]; ok {
+		entry := elem.Value.(*diskCacheEntry)
+		c.size -= entry.size
+		entry.size = size
+		c.lru.MoveToFront(elem)
+	} else {
+		c.items[name] = c.lru.PushFront(&diskCacheEntry{name: name, size: size})
+	}
+	c.size += size
+	c.evictLocked()
+
+	return nil
+}
+
+// remove drops a single entry from the index and disk.
+func (c *DiskCache) remove(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.items[name]; ok {
+		c.size -= elem.Value.(*diskCacheEntry).size
+		c.lru.Remove(elem)
+		delete(c.items, name)
+	}
+	os.Remove(filepath.Join(c.dir, name))
+}
+
+// evictLocked removes entries from the back of the LRU until the cache fits.
+// Callers must hold c.mu.
+func (c *DiskCache) evictLocked() {
+	for c.size > c.maxBytes {
+		elem := c.lru.Back()
+		if elem == nil {
+			return
+		}
+		entry := elem.Value.(*diskCacheEntry)
+		c.lru.Remove(elem)
+		delete(c.items, entry.name)
+		c.size -= entry.size
+		os.Remove(filepath.Join(c.dir, entry.name))
+	}
+}
+
+// cacheFileName maps an arbitrary key to a safe, fixed-length file name.
+func cacheFileName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -6,11 +6,13 @@ import (
 	"net/http"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/michael/flowreader/internal/readability"
 )
 
-// ContentExtractor extracts the main text content from a web page.
+// ContentExtractor extracts the main article content from a web page.
 type ContentExtractor struct {
 	client *http.Client
 }
@@ -23,20 +25,22 @@ func NewContentExtractor() *ContentExtractor {
 	}
 }
 
-// Extract fetches the URL and tries to extract the main article content.
-func (e *ContentExtractor) Extract(ctx context.Context, url string) (string, error) {
+// Extract fetches the URL and runs readability extraction on the page. The
+// returned Content is NOT sanitized; callers must run it through a
+// ContentSanitizer before storing or rendering it.
+func (e *ContentExtractor) Extract(ctx context.Context, url string) (*readability.Article, error) {
 	if url == "" {
-		return "", fmt.Errorf("empty URL")
+		return nil, fmt.Errorf("empty URL")
 	}
 
 	// Validate up-front (scheme + non-private host) before issuing the request.
 	if _, err := ValidateExternalURL(url); err != nil {
-		return "", err
+		return nil, err
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// Set a common User-Agent to avoid some basic bot detection
@@ -44,79 +48,45 @@ func (e *ContentExtractor) Extract(ctx context.Context, url string) (string, err
 
 	resp, err := e.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("fetching URL: %w", err)
+		return nil, fmt.Errorf("fetching URL: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
 	doc, err := goquery.NewDocumentFromReader(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("parsing HTML: %w", err)
+		return nil, fmt.Errorf("parsing HTML: %w", err)
 	}
 
-	// 1. Remove noise
-	doc.Find("script, style, nav, footer, header, aside, .ads, #comments, .sidebar").Remove()
-
-	// 2. Try to find the main content container
-	var content string
-
-	// Priority list of selectors for article content
-	selectors := []string{
-		"article",
-		"main",
-		".article-content",
-		".post-content",
-		".entry-content",
-		".content",
-		"#main-content",
-	}
-
-	for _, selector := range selectors {
-		selection := doc.Find(selector)
-		if selection.Length() > 0 {
-			// Get the longest piece of text if multiple matches
-			maxLength := 0
-			var bestSelection *goquery.Selection
-			selection.Each(func(i int, s *goquery.Selection) {
-				textLen := len(strings.TrimSpace(s.Text()))
-				if textLen > maxLength {
-					maxLength = textLen
-					bestSelection = s
-				}
-			})
-			if bestSelection != nil {
-				content = e.cleanText(bestSelection.Text())
-				if len(content) > 500 { // Heuristic: good enough
-					break
-				}
-			}
-		}
+	// Resolve relative links against the final (post-redirect) URL.
+	article, err := readability.Parse(doc, resp.Request.URL)
+	if err != nil {
+		return nil, fmt.Errorf("extracting article: %w", err)
 	}
 
-	// 3. Fallback: Body text if no container found or text too short
-	if len(content) < 500 {
-		content = e.cleanText(doc.Find("body").Text())
-	}
+	return article, nil
+}
 
-	// Limit to 10000 characters to avoid huge payloads to AI
-	if len(content) > 10000 {
-		content = content[:10000]
+// HTMLToText flattens an HTML fragment to plain text, one block per line.
+func HTMLToText(html string) string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return ""
 	}
-
-	return content, nil
+	return readability.ToText(doc.Find("body"))
 }
 
-func (e *ContentExtractor) cleanText(text string) string {
-	lines := strings.Split(text, "\n")
-	var cleaned []string
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		if trimmed != "" {
-			cleaned = append(cleaned, trimmed)
-		}
+// TruncateUTF8 shortens s to at most maxBytes bytes without splitting a
+// multi-byte character.
+func TruncateUTF8(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+	for maxBytes > 0 && !utf8.RuneStart(s[maxBytes]) {
+		maxBytes--
 	}
-	return strings.Join(cleaned, "\n")
+	return s[:maxBytes]
 }
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncateUTF8(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		maxBytes int
+		want     string
+	}{
+		{"short", "hello", 10, "hello"},
+		{"exact", "hello", 5, "hello"},
+		{"ascii cut", "hello world", 5, "hello"},
+		{"before multibyte", "café", 3, "caf"},
+		{"mid two-byte rune", "café", 4, "caf"},
+		{"after two-byte rune", "café", 5, "café"},
+		{"mid three-byte rune", "京都市", 4, "京"},
+		{"mid four-byte rune", "a🍁b", 3, "a"},
+		{"zero", "京都", 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruncateUTF8(tt.s, tt.maxBytes)
+			if got != tt.want {
+				t.Errorf("TruncateUTF8(%q, %d) = %q, want %q", tt.s, tt.maxBytes, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("TruncateUTF8(%q, %d) is not valid UTF-8", tt.s, tt.maxBytes)
+			}
+		})
+	}
+}
+
+func TestHTMLToText(t *testing.T) {
+	got := HTMLToText("<p>京都の<b>紅葉</b></p><p>second<br>line</p>")
+	want := "京都の紅葉\nsecond\nline"
+	if got != want {
+		t.Errorf("HTMLToText = %q, want %q", got, want)
+	}
+}
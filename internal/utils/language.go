@@ -0,0 +1,191 @@
+package utils
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// LanguageUndetermined is stored for articles whose language could not be
+// determined (BCP 47 "und"). They are indexed without stemming.
+const LanguageUndetermined = "und"
+
+// textSearchConfigs maps the languages with a PostgreSQL text search
+// configuration to its name. It mirrors the article_ts_config SQL function
+// (migration 020); any other language is indexed with "simple".
+var textSearchConfigs = map[string]string{
+	"da": "danish",
+	"de": "german",
+	"en": "english",
+	"es": "spanish",
+	"fi": "finnish",
+	"fr": "french",
+	"hu": "hungarian",
+	"it": "italian",
+	"nb": "norwegian",
+	"nl": "dutch",
+	"nn": "norwegian",
+	"no": "norwegian",
+	"pt": "portuguese",
+	"ro": "romanian",
+	"ru": "russian",
+	"sv": "swedish",
+	"tr": "turkish",
+}
+
+// TextSearchConfig returns the PostgreSQL text search configuration used for
+// a language code.
+func TextSearchConfig(lang string) string {
+	if cfg, ok := textSearchConfigs[lang]; ok {
+		return cfg
+	}
+	return "simple"
+}
+
+// TextSearchConfigs returns the distinct text search configurations of the
+// given languages, always including "simple" (undetected languages). With no
+// languages, every configuration is returned.
+func TextSearchConfigs(langs []string) []string {
+	seen := map[string]bool{"simple": true}
+	configs := []string{"simple"}
+	add := func(cfg string) {
+		if !seen[cfg] {
+			seen[cfg] = true
+			configs = append(configs, cfg)
+		}
+	}
+
+	if len(langs) == 0 {
+		for _, cfg := range textSearchConfigs {
+			add(cfg)
+		}
+	}
+	for _, lang := range langs {
+		add(TextSearchConfig(lang))
+	}
+
+	sort.Strings(configs)
+	return configs
+}
+
+// NormalizeLanguage reduces a declared language tag ("en-US", "fr_FR", "DE")
+// to its lowercase primary subtag, or "" when it is not a plausible tag.
+func NormalizeLanguage(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	if len(tag) < 2 || len(tag) > 3 {
+		return ""
+	}
+	for _, r := range tag {
+		if r < 'a' || r > 'z' {
+			return ""
+		}
+	}
+	return tag
+}
+
+// stopwords are frequent function words of the languages the statistical
+// detector knows. They are short and rarely shared, which makes counting
+// them a cheap and reliable signal on a few sentences of text.
+var stopwords = map[string][]string{
+	"en": {"the", "and", "of", "to", "is", "in", "that", "it", "for", "with", "was", "on", "are", "this", "be", "by", "have", "from", "or", "at", "which", "but", "not", "they", "you", "has", "were", "their", "been", "would", "will", "there", "what", "about", "when"},
+	"fr": {"le", "la", "les", "des", "est", "et", "une", "du", "dans", "qui", "que", "pour", "pas", "sur", "au", "avec", "sont", "ce", "il", "elle", "nous", "vous", "mais", "ou", "aux", "par", "cette", "ont", "été", "leur", "plus", "comme", "ses", "où", "être", "l", "d", "qu", "n", "j", "c"},
+	"de": {"der", "die", "und", "das", "ist", "nicht", "ein", "eine", "zu", "den", "mit", "von", "sich", "des", "auf", "für", "im", "dem", "auch", "es", "wird", "sie", "wir", "ich", "nach", "bei", "aus", "wie", "oder", "werden", "noch", "über", "einer", "hat", "sind"},
+	"es": {"el", "los", "las", "que", "del", "y", "en", "un", "una", "es", "por", "con", "para", "como", "pero", "más", "su", "sus", "al", "lo", "este", "esta", "son", "fue", "ha", "muy", "también", "sobre", "entre", "cuando", "porque", "está", "hay", "sin", "desde"},
+	"it": {"il", "di", "che", "è", "e", "la", "per", "un", "una", "non", "sono", "del", "della", "gli", "le", "nel", "alla", "con", "anche", "come", "più", "ma", "si", "dei", "delle", "questo", "questa", "ha", "essere", "lo", "nella", "sul", "hanno", "perché", "ancora", "dell", "nell", "all", "sull", "l"},
+	"pt": {"o", "os", "as", "que", "de", "do", "da", "dos", "das", "não", "uma", "um", "para", "com", "em", "por", "mais", "como", "mas", "foi", "ao", "ele", "ela", "seu", "sua", "ou", "quando", "muito", "já", "também", "são", "está", "isso", "pelo", "pela"},
+	"nl": {"de", "het", "een", "en", "van", "is", "niet", "dat", "op", "te", "zijn", "voor", "met", "die", "ook", "als", "maar", "om", "aan", "er", "bij", "door", "nog", "wordt", "naar", "uit", "wel", "dan", "worden", "heeft", "hij", "zij", "deze", "geen", "kan"},
+}
+
+// stopwordIndex maps each stopword to the languages it belongs to.
+var stopwordIndex = func() map[string][]string {
+	index := make(map[string][]string)
+	for lang, words := range stopwords {
+		for _, w := range words {
+			index[w] = append(index[w], lang)
+		}
+	}
+	return index
+}()
+
+var markupPattern = regexp.MustCompile(`<[^>]*>`)
+
+// Limits of the statistical detector
+const (
+	maxDetectionWords = 2000
+	minStopwordHits   = 3
+)
+
+// DetectLanguage returns the language of an article. The first declared
+// language (item, then feed) that normalizes to a known text search language
+// wins; otherwise the language is guessed from the text, which may contain
+// HTML. It returns LanguageUndetermined when the guess is not confident.
+func DetectLanguage(text string, declared ...string) string {
+	for _, tag := range declared {
+		if lang := NormalizeLanguage(tag); lang != "" {
+			if _, ok := textSearchConfigs[lang]; ok {
+				return lang
+			}
+		}
+	}
+	return GuessLanguage(text)
+}
+
+// GuessLanguage detects the language of a text from its script and its
+// stopwords.
+func GuessLanguage(text string) string {
+	text = markupPattern.ReplaceAllString(text, " ")
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	if len(words) > maxDetectionWords {
+		words = words[:maxDetectionWords]
+	}
+
+	scores := make(map[string]int)
+	cyrillic, latin := 0, 0
+	for _, w := range words {
+		// Elided articles and pronouns (l'article, qu'il, dell'anno)
+		if i := strings.IndexRune(w, '\''); i >= 0 {
+			w = w[:i]
+		}
+		for _, lang := range stopwordIndex[w] {
+			scores[lang]++
+		}
+		for _, r := range w {
+			if unicode.Is(unicode.Cyrillic, r) {
+				cyrillic++
+			} else if unicode.Is(unicode.Latin, r) {
+				latin++
+			}
+			break
+		}
+	}
+
+	if cyrillic > latin {
+		return "ru"
+	}
+
+	best, bestScore := "", 0
+	for lang, score := range scores {
+		if score > bestScore || (score == bestScore && lang < best) {
+			best, bestScore = lang, score
+		}
+	}
+	secondScore := 0
+	for lang, score := range scores {
+		if lang != best && score > secondScore {
+			secondScore = score
+		}
+	}
+
+	// Require a few hits and a clear margin over the runner-up
+	if bestScore < minStopwordHits || bestScore*4 < secondScore*5 {
+		return LanguageUndetermined
+	}
+	return best
+}
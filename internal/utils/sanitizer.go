@@ -2,26 +2,183 @@
 package utils
 
 import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
 	"github.com/microcosm-cc/bluemonday"
 )
 
+// SanitizerPolicyRevision identifies the current sanitization rules. Bump it
+// whenever the rules change so stored articles get re-sanitized; the embed
+// allowlist is part of the policy version on its own (see Version).
+const SanitizerPolicyRevision = 2
+
+// DefaultEmbedOrigins are the iframe origins allowed out of the box. More can
+// be added through configuration (e.g. PeerTube instances).
+var DefaultEmbedOrigins = []string{
+	"https://www.youtube.com",
+	"https://www.youtube-nocookie.com",
+	"https://player.vimeo.com",
+}
+
+// embedSandbox is the sandbox applied to every allowed iframe. Players need
+// scripts and their own origin; the embed cannot navigate the reader.
+const embedSandbox = "allow-scripts allow-same-origin allow-popups allow-presentation"
+
+var (
+	// Comma-separated http(s) or root-relative candidates with an optional
+	// width or density descriptor.
+	srcsetPattern = regexp.MustCompile(`^\s*(?:https?://|/)[^\s,]+(?:\s+\d+(?:\.\d+)?[wx])?\s*(?:,\s*(?:https?://|/)[^\s,]+(?:\s+\d+(?:\.\d+)?[wx])?\s*)*$`)
+	// Media queries and source sizes, e.g. "(max-width: 600px) 480px, 800px".
+	mediaPattern     = regexp.MustCompile(`^[a-zA-Z0-9\s(),.:%-]+$`)
+	mimeTypePattern  = regexp.MustCompile(`^[a-zA-Z0-9]+/[a-zA-Z0-9.+-]+$`)
+	httpURLPattern   = regexp.MustCompile(`^https?://[^\s"'<>]+$`)
+	preloadPattern   = regexp.MustCompile(`^(?:none|metadata|auto)$`)
+	trackKindPattern = regexp.MustCompile(`^(?:subtitles|captions|descriptions|chapters|metadata)$`)
+	langPattern      = regexp.MustCompile(`^[a-zA-Z]{2,3}(?:-[a-zA-Z0-9]{2,8})*$`)
+)
+
 // ContentSanitizer handles HTML sanitization for articles.
 type ContentSanitizer struct {
-	policy *bluemonday.Policy
+	policy  *bluemonday.Policy
+	version int
 }
 
-// NewContentSanitizer creates a new sanitizer with a "UGCPolicy" (safe for user-generated content).
-func NewContentSanitizer() *ContentSanitizer {
-	// Using UGCPolicy allows common tags (b, i, p, img, etc.) but strips dangerous ones.
+// NewContentSanitizer creates a sanitizer with the FlowReader policy: the
+// bluemonday UGC policy extended with responsive images, media elements and
+// sandboxed iframes from the default and given embed origins.
+func NewContentSanitizer(embedOrigins ...string) *ContentSanitizer {
+	p := bluemonday.UGCPolicy()
+
+	// Figures and responsive images
+	p.AllowElements("figure", "figcaption", "picture")
+	p.AllowAttrs("srcset").Matching(srcsetPattern).OnElements("img", "source")
+	p.AllowAttrs("sizes").Matching(mediaPattern).OnElements("img", "source")
+	p.AllowAttrs("media").Matching(mediaPattern).OnElements("source")
+	p.AllowAttrs("type").Matching(mimeTypePattern).OnElements("source")
+
+	// Media elements (no autoplay)
+	p.AllowAttrs("src").OnElements("video", "audio", "source", "track")
+	p.AllowAttrs("controls", "loop", "muted").OnElements("video", "audio")
+	p.AllowAttrs("preload").Matching(preloadPattern).OnElements("video", "audio")
+	p.AllowAttrs("playsinline").OnElements("video")
+	p.AllowAttrs("poster").Matching(httpURLPattern).OnElements("video")
+	p.AllowAttrs("width", "height").Matching(bluemonday.Integer).OnElements("video")
+	p.AllowAttrs("kind").Matching(trackKindPattern).OnElements("track")
+	p.AllowAttrs("srclang").Matching(langPattern).OnElements("track")
+	p.AllowAttrs("label").Matching(bluemonday.Paragraph).OnElements("track")
+	p.AllowAttrs("default").OnElements("track")
+
+	// Embeds: only allowlisted origins, always sandboxed
+	origins := normalizeEmbedOrigins(append(append([]string{}, DefaultEmbedOrigins...), embedOrigins...))
+	p.AllowAttrs("src").Matching(embedOriginPattern(origins)).OnElements("iframe")
+	p.AllowAttrs("width", "height").Matching(bluemonday.Integer).OnElements("iframe")
+	p.AllowAttrs("title").Matching(bluemonday.Paragraph).OnElements("iframe")
+	p.AllowAttrs("allowfullscreen").OnElements("iframe")
+	p.RequireSandboxOnIFrame()
+
 	return &ContentSanitizer{
-		policy: bluemonday.UGCPolicy(),
+		policy:  p,
+		version: policyVersion(origins),
 	}
 }
 
+// Version identifies the policy of this sanitizer: a hash of the rule
+// revision and the embed allowlist, so that changing either re-sanitizes
+// stored articles. It is never 0, the version of unsanitized rows.
+func (s *ContentSanitizer) Version() int {
+	return s.version
+}
+
+func policyVersion(origins []string) int {
+	h := fnv.New32a()
+	fmt.Fprintf(h, "revision %d\n", SanitizerPolicyRevision)
+	for _, origin := range origins {
+		fmt.Fprintf(h, "embed %s\n", origin)
+	}
+	version := int(h.Sum32() & 0x7fffffff)
+	if version == 0 {
+		version = 1
+	}
+	return version
+}
+
+// normalizeEmbedOrigins lowercases the https origins, drops the others and
+// duplicates, and sorts them.
+func normalizeEmbedOrigins(origins []string) []string {
+	seen := make(map[string]bool)
+	var normalized []string
+	for _, origin := range origins {
+		origin = strings.ToLower(strings.TrimRight(strings.TrimSpace(origin), "/"))
+		if !strings.HasPrefix(origin, "https://") || seen[origin] {
+			continue
+		}
+		seen[origin] = true
+		normalized = append(normalized, origin)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+// embedOriginPattern matches URLs on one of the given normalized origins,
+// case-insensitively.
+func embedOriginPattern(origins []string) *regexp.Regexp {
+	alternatives := make([]string, len(origins))
+	for i, origin := range origins {
+		alternatives[i] = regexp.QuoteMeta(origin)
+	}
+	return regexp.MustCompile(`(?i)^(?:` + strings.Join(alternatives, "|") + `)/`)
+}
+
 // Sanitize cleans the HTML content.
 func (s *ContentSanitizer) Sanitize(html string) string {
 	if html == "" {
 		return ""
 	}
-	return s.policy.Sanitize(html)
+	return finalizeHTML(s.policy.Sanitize(html))
+}
+
+// finalizeHTML applies the attributes bluemonday cannot add on its own:
+// links open in a new tab without leaking the opener or referrer, images and
+// embeds load lazily, embeds get a fixed sandbox, media gets native controls, and iframes whose source
+// was rejected are dropped.
+func finalizeHTML(fragment string) string {
+	if !strings.Contains(fragment, "<") {
+		return fragment
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(fragment))
+	if err != nil {
+		return fragment
+	}
+
+	doc.Find("a[href]").Each(func(_ int, a *goquery.Selection) {
+		a.SetAttr("target", "_blank")
+		a.SetAttr("rel", "nofollow noopener noreferrer")
+	})
+	doc.Find("iframe").Each(func(_ int, iframe *goquery.Selection) {
+		if _, ok := iframe.Attr("src"); !ok {
+			iframe.Remove()
+			return
+		}
+		iframe.SetAttr("sandbox", embedSandbox)
+		iframe.SetAttr("loading", "lazy")
+	})
+	doc.Find("img").Each(func(_ int, img *goquery.Selection) {
+		img.SetAttr("loading", "lazy")
+		img.SetAttr("decoding", "async")
+	})
+	doc.Find("video, audio").Each(func(_ int, media *goquery.Selection) {
+		media.SetAttr("controls", "")
+	})
+
+	out, err := doc.Find("body").Html()
+	if err != nil {
+		return fragment
+	}
+	return out
 }
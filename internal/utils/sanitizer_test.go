@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// forbiddenPatterns must never survive sanitization, whatever the payload.
+var forbiddenPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)<script`),
+	regexp.MustCompile(`(?i)<style`),
+	regexp.MustCompile(`(?i)<svg`),
+	regexp.MustCompile(`(?i)<math`),
+	regexp.MustCompile(`(?i)\son[a-z]+\s*=`),
+	regexp.MustCompile(`(?i)javascript:`),
+	regexp.MustCompile(`(?i)(?:href|src|srcset)="data:`),
+	regexp.MustCompile(`(?i)srcdoc`),
+	regexp.MustCompile(`(?i)\sstyle=`),
+}
+
+func TestSanitizeXSSPayloads(t *testing.T) {
+	s := NewContentSanitizer("https://peertube.example")
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		// Links
+		{"javascript href", `<a href="javascript:alert(1)">x</a>`, `x`},
+		{"mixed case javascript href", `<a href="JaVaScRiPt:alert(1)">x</a>`, `x`},
+		{"entity-encoded javascript href", `<a href="&#106;avascript:alert(1)">x</a>`, `x`},
+		{"javascript href with whitespace", `<a href=" javascript:alert(1)">x</a>`, `x`},
+		{"data href", `<a href="data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg==">x</a>`, `x`},
+		{"data image src", `<img src="data:image/svg+xml;base64,PHN2Zz4=">`, ``},
+
+		// srcset
+		{"javascript srcset", `<img src="https://e.com/a.jpg" srcset="javascript:alert(1) 1x">`,
+			`<img src="https://e.com/a.jpg" loading="lazy" decoding="async"/>`},
+		{"attribute breakout in srcset", `<img src="https://e.com/a.jpg" srcset="https://e.com/a.jpg 1x, https://e.com/b.jpg&quot; onerror=&quot;alert(1) 2x">`,
+			`<img src="https://e.com/a.jpg" loading="lazy" decoding="async"/>`},
+		{"data srcset on source", `<picture><source srcset="data:image/png;base64,AA" type="image/png"><img src="https://e.com/a.jpg"></picture>`,
+			`<picture><source type="image/png"/><img src="https://e.com/a.jpg" loading="lazy" decoding="async"/></picture>`},
+		{"valid srcset kept", `<img src="https://e.com/a.jpg" srcset="https://e.com/a.jpg 1x, https://e.com/b.jpg 2x">`,
+			`<img src="https://e.com/a.jpg" srcset="https://e.com/a.jpg 1x, https://e.com/b.jpg 2x" loading="lazy" decoding="async"/>`},
+
+		// Event handlers
+		{"onerror", `<img src="https://e.com/a.jpg" onerror="alert(1)">`, `<img src="https://e.com/a.jpg" loading="lazy" decoding="async"/>`},
+		{"onclick and onmouseover", `<p onclick="alert(1)" onmouseover="x">t</p>`, `<p>t</p>`},
+		{"body onload", `<body onload="alert(1)"><p>t</p></body>`, `<p>t</p>`},
+		{"media handlers and autoplay", `<video src="https://e.com/v.mp4" autoplay onplay="x"></video>`, `<video src="https://e.com/v.mp4" controls=""></video>`},
+		{"style attribute", `<p style="background:url(javascript:alert(1))">s</p>`, `<p>s</p>`},
+
+		// Embeds
+		{"iframe off the allowlist", `<iframe src="https://evil.example/embed"></iframe>`, ``},
+		{"iframe on a lookalike host", `<iframe src="https://www.youtube.com.evil.example/embed/x"></iframe>`, ``},
+		{"iframe over http", `<iframe src="http://www.youtube.com/embed/abc"></iframe>`, ``},
+		{"iframe without src", `<iframe srcdoc="<script>alert(1)</script>"></iframe>`, ``},
+		{"allowed iframe gets the fixed sandbox", `<iframe src="https://www.youtube.com/embed/abc" sandbox="allow-top-navigation allow-scripts"></iframe>`,
+			`<iframe src="https://www.youtube.com/embed/abc" sandbox="allow-scripts allow-same-origin allow-popups allow-presentation" loading="lazy"></iframe>`},
+		{"configured embed origin", `<iframe src="https://peertube.example/videos/embed/1"></iframe>`,
+			`<iframe src="https://peertube.example/videos/embed/1" sandbox="allow-scripts allow-same-origin allow-popups allow-presentation" loading="lazy"></iframe>`},
+
+		// Namespace confusion and mutation XSS
+		{"svg script", `<svg><script>alert(1)</script></svg>`, ``},
+		{"svg style mutation", `<svg><p><style><img src=x onerror=alert(1)></style></p></svg>`, `<p></p>`},
+		{"math mglyph mutation", `<math><mtext><table><mglyph><style><img src=x onerror=alert(1)></style></mglyph></table></mtext></math>`, `<table></table>`},
+		{"math xlink href", `<math><mi xlink:href="javascript:alert(1)">x</mi></math>`, `x`},
+		{"noscript attribute breakout", `<noscript><p title="</noscript><img src=x onerror=alert(1)>"></noscript>`, `<img src="x" loading="lazy" decoding="async"/>&#34;&gt;`},
+		{"script and style elements", `<script>alert(1)</script><style>body{}</style><p>ok</p>`, `<p>ok</p>`},
+
+		// Opener and referrer
+		{"target blank added", `<a href="https://example.com/">ok</a>`,
+			`<a href="https://example.com/" rel="nofollow noopener noreferrer" target="_blank">ok</a>`},
+		{"target and rel overridden", `<a href="https://example.com" target="_self" rel="opener">x</a>`,
+			`<a href="https://example.com" rel="nofollow noopener noreferrer" target="_blank">x</a>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.Sanitize(tt.in)
+			if got != tt.want {
+				t.Errorf("Sanitize(%s)\n got %s\nwant %s", tt.in, got, tt.want)
+			}
+			for _, p := range forbiddenPatterns {
+				if p.MatchString(got) {
+					t.Errorf("Sanitize(%s) = %s, matches %s", tt.in, got, p)
+				}
+			}
+		})
+	}
+}
+
+// TestSanitizeLinksOpenSafely checks every link, whatever its attributes,
+// opens in a new tab without access to the opener.
+func TestSanitizeLinksOpenSafely(t *testing.T) {
+	s := NewContentSanitizer()
+	out := s.Sanitize(`<p><a href="https://a.example">a</a> <a href="/b" target="_top">b</a> <a href="mailto:x@example.com" rel="">c</a></p>`)
+
+	links := regexp.MustCompile(`<a [^>]*>`).FindAllString(out, -1)
+	if len(links) != 3 {
+		t.Fatalf("got %d links in %s", len(links), out)
+	}
+	for _, a := range links {
+		if !strings.Contains(a, `target="_blank"`) || !strings.Contains(a, `rel="nofollow noopener noreferrer"`) {
+			t.Errorf("link %s is missing target or rel", a)
+		}
+	}
+}
+
+func TestSanitizerVersion(t *testing.T) {
+	base := NewContentSanitizer()
+	if base.Version() == 0 {
+		t.Fatal("Version is 0, the version of unsanitized rows")
+	}
+	if v := NewContentSanitizer().Version(); v != base.Version() {
+		t.Errorf("same policy: Version %d != %d", v, base.Version())
+	}
+	if v := NewContentSanitizer("https://www.youtube.com/", "HTTPS://PLAYER.VIMEO.COM").Version(); v != base.Version() {
+		t.Errorf("default origins spelled differently: Version %d != %d", v, base.Version())
+	}
+	if v := NewContentSanitizer("https://peertube.example").Version(); v == base.Version() {
+		t.Error("adding an embed origin did not change Version")
+	}
+	if a, b := NewContentSanitizer("https://a.example", "https://b.example").Version(), NewContentSanitizer("https://b.example", "https://a.example").Version(); a != b {
+		t.Errorf("origin order changed Version: %d != %d", a, b)
+	}
+}
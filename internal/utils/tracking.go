@@ -0,0 +1,234 @@
+package utils
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Default tracking rules. Deployments can extend them through configuration.
+var (
+	defaultTrackingParams = []string{
+		"utm_*", "fbclid", "gclid", "dclid", "gbraid", "wbraid", "msclkid", "yclid",
+		"mc_cid", "mc_eid", "_hsenc", "_hsmi", "__hssc", "__hstc", "__hsfp", "hsctatracking",
+		"mkt_tok", "igshid", "oly_anon_id", "oly_enc_id", "vero_id", "vero_conv",
+		"wt.mc_id", "ncid", "sr_share", "ref_src", "ref_url", "_openstat",
+		"pk_*", "mtm_*", "piwik_*", "matomo_*", "ga_*", "_ga", "_gl", "s_cid", "cmpid",
+	}
+
+	// defaultRedirectors maps "host/path" prefixes of redirect wrappers to the
+	// query parameters that carry the real destination.
+	defaultRedirectors = []string{
+		"www.google.com/url=q|url",
+		"google.com/url=q|url",
+		"l.facebook.com/l.php=u",
+		"lm.facebook.com/l.php=u",
+		"l.instagram.com=u",
+		"out.reddit.com=url",
+		"t.umblr.com/redirect=z",
+		"www.youtube.com/redirect=q",
+		"steamcommunity.com/linkfilter=url",
+		"slack-redir.net/link=url",
+		"getpocket.com/redirect=url",
+		"click.linksynergy.com=murl",
+		"www.awin1.com/cread.php=ued",
+	}
+
+	// defaultPixelHosts are "host/path" prefixes of known tracking beacons.
+	defaultPixelHosts = []string{
+		"feeds.feedburner.com/~r/",
+		"feeds.feedburner.com/~ff/",
+		"feedproxy.google.com/~r/",
+		"pixel.wp.com",
+		"stats.wordpress.com",
+		"pixel.quantserve.com",
+		"www.google-analytics.com",
+		"list-manage.com/track/open",
+		"www.facebook.com/tr",
+		"pi.feedsportal.com",
+		"rss.buysellads.com",
+	}
+)
+
+// TrackingRules configures a TrackingFilter. Each list is added to the
+// built-in defaults.
+type TrackingRules struct {
+	// Params are query parameter names to strip; a trailing "*" matches a prefix.
+	Params []string
+	// Redirectors are "host/path=param|param" entries naming redirect wrappers
+	// and the parameters holding the destination URL.
+	Redirectors []string
+	// PixelHosts are "host/path" prefixes whose images are tracking beacons.
+	PixelHosts []string
+}
+
+// TrackingFilter canonicalizes URLs and strips tracking from article HTML:
+// tracking query parameters, redirect wrappers and 1x1 beacon images.
+type TrackingFilter struct {
+	params      map[string]bool
+	prefixes    []string
+	redirectors map[string][]string
+	pixelHosts  []string
+}
+
+// NewTrackingFilter builds a filter from the defaults plus extra rules.
+func NewTrackingFilter(rules TrackingRules) *TrackingFilter {
+	f := &TrackingFilter{
+		params:      make(map[string]bool),
+		redirectors: make(map[string][]string),
+	}
+
+	for _, p := range append(append([]string{}, defaultTrackingParams...), rules.Params...) {
+		p = strings.ToLower(strings.TrimSpace(p))
+		if p == "" {
+			continue
+		}
+		if strings.HasSuffix(p, "*") {
+			f.prefixes = append(f.prefixes, strings.TrimSuffix(p, "*"))
+		} else {
+			f.params[p] = true
+		}
+	}
+
+	for _, r := range append(append([]string{}, defaultRedirectors...), rules.Redirectors...) {
+		prefix, params, ok := strings.Cut(strings.TrimSpace(r), "=")
+		if !ok || prefix == "" || params == "" {
+			continue
+		}
+		f.redirectors[strings.ToLower(strings.TrimSuffix(prefix, "/"))] = strings.Split(params, "|")
+	}
+
+	for _, h := range append(append([]string{}, defaultPixelHosts...), rules.PixelHosts...) {
+		if h = strings.ToLower(strings.TrimSpace(h)); h != "" {
+			f.pixelHosts = append(f.pixelHosts, h)
+		}
+	}
+
+	return f
+}
+
+// isTrackingParam reports whether a query parameter should be stripped.
+func (f *TrackingFilter) isTrackingParam(name string) bool {
+	name = strings.ToLower(name)
+	if f.params[name] {
+		return true
+	}
+	for _, prefix := range f.prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// CleanURL returns the canonical form of raw: redirect wrappers are unwrapped
+// and tracking parameters removed. Non-http(s) or unparseable URLs are
+// returned unchanged.
+func (f *TrackingFilter) CleanURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return raw
+	}
+
+	// Unwrap nested redirectors (bounded, in case of loops).
+	for i := 0; i < 3; i++ {
+		target := f.unwrap(u)
+		if target == nil {
+			break
+		}
+		u = target
+	}
+
+	if u.RawQuery != "" {
+		query := u.Query()
+		changed := false
+		for name := range query {
+			if f.isTrackingParam(name) {
+				query.Del(name)
+				changed = true
+			}
+		}
+		if changed {
+			u.RawQuery = query.Encode()
+		}
+	}
+
+	// Fragments like #xtor=RSS-1 are tracking too.
+	if strings.Contains(u.Fragment, "=") {
+		u.Fragment = ""
+	}
+
+	return u.String()
+}
+
+// unwrap returns the destination of a known redirect wrapper, or nil.
+func (f *TrackingFilter) unwrap(u *url.URL) *url.URL {
+	key := strings.ToLower(u.Host + strings.TrimSuffix(u.Path, "/"))
+	for prefix, params := range f.redirectors {
+		if key != prefix && !strings.HasPrefix(key, prefix+"/") {
+			continue
+		}
+		query := u.Query()
+		for _, p := range params {
+			target, err := url.Parse(query.Get(p))
+			if err == nil && (target.Scheme == "http" || target.Scheme == "https") && target.Host != "" {
+				return target
+			}
+		}
+	}
+	return nil
+}
+
+// isPixel reports whether an image is a tracking beacon.
+func (f *TrackingFilter) isPixel(img *goquery.Selection) bool {
+	width, wErr := strconv.Atoi(strings.TrimSuffix(img.AttrOr("width", ""), "px"))
+	height, hErr := strconv.Atoi(strings.TrimSuffix(img.AttrOr("height", ""), "px"))
+	if wErr == nil && hErr == nil && width <= 1 && height <= 1 {
+		return true
+	}
+
+	return f.IsPixelURL(img.AttrOr("src", ""))
+}
+
+// IsPixelURL reports whether an image URL points at a known tracking beacon.
+func (f *TrackingFilter) IsPixelURL(src string) bool {
+	src = strings.ToLower(strings.TrimSpace(src))
+	src = strings.TrimPrefix(strings.TrimPrefix(src, "https://"), "http://")
+	for _, host := range f.pixelHosts {
+		if strings.HasPrefix(src, host) || strings.Contains(src, "."+host) {
+			return true
+		}
+	}
+	return false
+}
+
+// CleanHTML canonicalizes links and removes tracking pixels in an HTML
+// fragment.
+func (f *TrackingFilter) CleanHTML(fragment string) string {
+	if fragment == "" || !strings.Contains(fragment, "<") {
+		return fragment
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(fragment))
+	if err != nil {
+		return fragment
+	}
+
+	doc.Find("img").Each(func(_ int, img *goquery.Selection) {
+		if f.isPixel(img) {
+			img.Remove()
+		}
+	})
+	doc.Find("a[href]").Each(func(_ int, a *goquery.Selection) {
+		a.SetAttr("href", f.CleanURL(a.AttrOr("href", "")))
+	})
+
+	out, err := doc.Find("body").Html()
+	if err != nil {
+		return fragment
+	}
+	return out
+}
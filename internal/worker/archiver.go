@@ -0,0 +1,94 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/michael/flowreader/internal/service"
+)
+
+// Archiver captures the archive snapshot of newly favorited articles, and of
+// favorites saved before archiving existed.
+type Archiver struct {
+	service   *service.ArchiveService
+	interval  time.Duration
+	batchSize int
+	stopCh    chan struct{}
+	wg        sync.WaitGroup
+}
+
+// NewArchiver creates a new favorite archiving worker.
+func NewArchiver(archiveService *service.ArchiveService, interval time.Duration, batchSize int) *Archiver {
+	return &Archiver{
+		service:   archiveService,
+		interval:  interval,
+		batchSize: batchSize,
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// Start begins the background loop.
+func (a *Archiver) Start() {
+	a.wg.Add(1)
+	go a.run()
+	log.Printf("Archive worker started (interval: %s)", a.interval)
+}
+
+// Stop gracefully stops the worker.
+func (a *Archiver) Stop() {
+	close(a.stopCh)
+	a.wg.Wait()
+	log.Println("Archive worker stopped")
+}
+
+func (a *Archiver) run() {
+	defer a.wg.Done()
+
+	a.archivePending()
+
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			a.archivePending()
+		case <-a.stopCh:
+			return
+		}
+	}
+}
+
+func (a *Archiver) archivePending() {
+	// Cancel in-flight downloads on shutdown
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-a.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	total := 0
+	for {
+		count, err := a.service.ArchivePendingFavorites(ctx, a.batchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Archive worker error: %v", err)
+			}
+			break
+		}
+		total += count
+		if count < a.batchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		log.Printf("Archive worker: archived %d favorites", total)
+	}
+}
@@ -6,19 +6,20 @@ import (
 	"sync"
 	"time"
 
-	"github.com/michael/flowreader/internal/repository"
+	"github.com/michael/flowreader/internal/domain"
 )
 
-// Cleaner handles periodic database maintenance.
+// Cleaner handles periodic database maintenance: it deletes the articles
+// that the retention policies no longer keep.
 type Cleaner struct {
-	repo     *repository.ArticleRepository
+	repo     domain.RetentionRepository
 	interval time.Duration
 	stopCh   chan struct{}
 	wg       sync.WaitGroup
 }
 
 // NewCleaner creates a new database cleaner worker.
-func NewCleaner(repo *repository.ArticleRepository, interval time.Duration) *Cleaner {
+func NewCleaner(repo domain.RetentionRepository, interval time.Duration) *Cleaner {
 	return &Cleaner{
 		repo:     repo,
 		interval: interval,
@@ -63,8 +64,7 @@ func (c *Cleaner) cleanup() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
 	defer cancel()
 
-	// Delete articles older than 30 days
-	count, err := c.repo.DeleteOldArticles(ctx, 30*24*time.Hour)
+	count, err := c.repo.Purge(ctx)
 	if err != nil {
 		log.Printf("Maintenance cleanup error: %v", err)
 		return
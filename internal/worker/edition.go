@@ -0,0 +1,95 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/michael/flowreader/internal/service"
+)
+
+// EditionGenerator generates the scheduled daily EPUB editions into the
+// users' download slots, and the editions users request, one at a time.
+type EditionGenerator struct {
+	service  *service.EPUBService
+	interval time.Duration
+	stopCh   chan struct{}
+	wg       sync.WaitGroup
+
+	// ctx cancels in-flight generations on shutdown
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewEditionGenerator creates a new daily edition worker.
+func NewEditionGenerator(epubService *service.EPUBService, interval time.Duration) *EditionGenerator {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &EditionGenerator{
+		service:  epubService,
+		interval: interval,
+		stopCh:   make(chan struct{}),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Start begins the background loops.
+func (g *EditionGenerator) Start() {
+	g.wg.Add(2)
+	go g.run()
+	go g.serveRequests()
+	log.Printf("Edition worker started (interval: %s)", g.interval)
+}
+
+// Stop gracefully stops the worker.
+func (g *EditionGenerator) Stop() {
+	close(g.stopCh)
+	g.cancel()
+	g.wg.Wait()
+	log.Println("Edition worker stopped")
+}
+
+func (g *EditionGenerator) run() {
+	defer g.wg.Done()
+
+	g.generateDue()
+
+	ticker := time.NewTicker(g.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			g.generateDue()
+		case <-g.stopCh:
+			return
+		}
+	}
+}
+
+func (g *EditionGenerator) generateDue() {
+	count, err := g.service.GenerateDueEditions(g.ctx, time.Now())
+	if err != nil && g.ctx.Err() == nil {
+		log.Printf("Edition worker error: %v", err)
+	}
+	if count > 0 {
+		log.Printf("Edition worker: generated %d daily editions", count)
+	}
+}
+
+// serveRequests generates the editions users asked for, in order.
+func (g *EditionGenerator) serveRequests() {
+	defer g.wg.Done()
+
+	for {
+		select {
+		case userID := <-g.service.EditionRequests():
+			if err := g.service.GenerateRequestedEdition(g.ctx, userID); err != nil && g.ctx.Err() == nil {
+				log.Printf("Edition worker: daily edition of user %s failed: %v", userID, err)
+			}
+		case <-g.stopCh:
+			return
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/michael/flowreader/internal/repository"
+	"github.com/michael/flowreader/internal/utils"
+)
+
+// LanguageBackfill detects the language of articles ingested before language
+// detection existed, which re-indexes them for full-text search. Like the
+// Resanitizer it runs once at startup and stops when every row is done.
+type LanguageBackfill struct {
+	articleRepo *repository.ArticleRepository
+	feedRepo    *repository.FeedRepository
+	batchSize   int
+	stopCh      chan struct{}
+	wg          sync.WaitGroup
+}
+
+// NewLanguageBackfill creates a new language backfill worker.
+func NewLanguageBackfill(articleRepo *repository.ArticleRepository, feedRepo *repository.FeedRepository, batchSize int) *LanguageBackfill {
+	return &LanguageBackfill{
+		articleRepo: articleRepo,
+		feedRepo:    feedRepo,
+		batchSize:   batchSize,
+		stopCh:      make(chan struct{}),
+	}
+}
+
+// Start begins detecting languages in the background.
+func (b *LanguageBackfill) Start() {
+	b.wg.Add(1)
+	go b.run()
+	log.Println("Language backfill started")
+}
+
+// Stop gracefully stops the backfill.
+func (b *LanguageBackfill) Stop() {
+	close(b.stopCh)
+	b.wg.Wait()
+	log.Println("Language backfill stopped")
+}
+
+func (b *LanguageBackfill) run() {
+	defer b.wg.Done()
+
+	total := 0
+	for {
+		select {
+		case <-b.stopCh:
+			return
+		default:
+		}
+
+		count, err := b.processBatch()
+		if err != nil {
+			log.Printf("Language backfill error: %v", err)
+			return
+		}
+		total += count
+		if count < b.batchSize {
+			break
+		}
+
+		// Re-indexing is write-heavy; yield between batches
+		select {
+		case <-time.After(100 * time.Millisecond):
+		case <-b.stopCh:
+			return
+		}
+	}
+
+	if total > 0 {
+		log.Printf("Language backfill: detected %d articles", total)
+	}
+}
+
+func (b *LanguageBackfill) processBatch() (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	items, err := b.articleRepo.GetUndetectedLanguage(ctx, b.batchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(items) == 0 {
+		return 0, nil
+	}
+
+	feedLanguages := make(map[uuid.UUID][]string)
+	seen := make(map[uuid.UUID]map[string]bool)
+	for _, item := range items {
+		item.Language = utils.GuessLanguage(item.Title + "\n" + item.Summary + "\n" + item.Content)
+		if seen[item.FeedID] == nil {
+			seen[item.FeedID] = make(map[string]bool)
+		}
+		if !seen[item.FeedID][item.Language] {
+			seen[item.FeedID][item.Language] = true
+			feedLanguages[item.FeedID] = append(feedLanguages[item.FeedID], item.Language)
+		}
+	}
+
+	// Record the feed languages first so that searches already expand over
+	// them when the articles are re-indexed
+	for feedID, languages := range feedLanguages {
+		if err := b.feedRepo.AddLanguages(feedID, languages); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := b.articleRepo.UpdateLanguages(ctx, items); err != nil {
+		return 0, err
+	}
+
+	return len(items), nil
+}
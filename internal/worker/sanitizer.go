@@ -0,0 +1,106 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/michael/flowreader/internal/repository"
+	"github.com/michael/flowreader/internal/utils"
+)
+
+// Resanitizer re-sanitizes stored articles whose HTML was cleaned with
+// another sanitizer policy, from the HTML as received when it was kept. The
+// policy only changes between releases or with the embed allowlist, so it
+// runs once at startup and stops when every row is up to date.
+type Resanitizer struct {
+	repo      *repository.ArticleRepository
+	sanitizer *utils.ContentSanitizer
+	batchSize int
+	stopCh    chan struct{}
+	wg        sync.WaitGroup
+}
+
+// NewResanitizer creates a new re-sanitization worker.
+func NewResanitizer(repo *repository.ArticleRepository, sanitizer *utils.ContentSanitizer, batchSize int) *Resanitizer {
+	return &Resanitizer{
+		repo:      repo,
+		sanitizer: sanitizer,
+		batchSize: batchSize,
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// Start begins re-sanitizing stale articles in the background.
+func (s *Resanitizer) Start() {
+	s.wg.Add(1)
+	go s.run()
+	log.Printf("Resanitizer started (policy version: %d)", s.sanitizer.Version())
+}
+
+// Stop gracefully stops the resanitizer.
+func (s *Resanitizer) Stop() {
+	close(s.stopCh)
+	s.wg.Wait()
+	log.Println("Resanitizer stopped")
+}
+
+func (s *Resanitizer) run() {
+	defer s.wg.Done()
+
+	total := 0
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		default:
+		}
+
+		count, err := s.processBatch()
+		if err != nil {
+			log.Printf("Resanitizer error: %v", err)
+			return
+		}
+		total += count
+		if count < s.batchSize {
+			break
+		}
+
+		// Yield between batches so reads are not starved on small hosts
+		select {
+		case <-time.After(100 * time.Millisecond):
+		case <-s.stopCh:
+			return
+		}
+	}
+
+	if total > 0 {
+		log.Printf("Resanitizer: updated %d articles", total)
+	}
+}
+
+func (s *Resanitizer) processBatch() (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	items, err := s.repo.GetStaleSanitized(ctx, s.sanitizer.Version(), s.batchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(items) == 0 {
+		return 0, nil
+	}
+
+	for _, item := range items {
+		item.Content = s.sanitizer.Sanitize(item.Content)
+		item.Summary = s.sanitizer.Sanitize(item.Summary)
+		item.FullContent = s.sanitizer.Sanitize(item.FullContent)
+	}
+
+	if err := s.repo.UpdateSanitized(ctx, items, s.sanitizer.Version()); err != nil {
+		return 0, err
+	}
+
+	return len(items), nil
+}
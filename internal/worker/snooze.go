@@ -0,0 +1,82 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/michael/flowreader/internal/repository"
+	"github.com/michael/flowreader/internal/ws"
+)
+
+// SnoozeWaker brings snoozed read-later articles back as unread once their
+// snooze expires and notifies the owners' clients.
+type SnoozeWaker struct {
+	repo     *repository.ReadLaterRepository
+	hub      *ws.Hub
+	interval time.Duration
+	stopCh   chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewSnoozeWaker creates a new snooze worker.
+func NewSnoozeWaker(repo *repository.ReadLaterRepository, hub *ws.Hub, interval time.Duration) *SnoozeWaker {
+	return &SnoozeWaker{
+		repo:     repo,
+		hub:      hub,
+		interval: interval,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Start begins the background loop.
+func (s *SnoozeWaker) Start() {
+	s.wg.Add(1)
+	go s.run()
+	log.Printf("Snooze worker started (interval: %s)", s.interval)
+}
+
+// Stop gracefully stops the worker.
+func (s *SnoozeWaker) Stop() {
+	close(s.stopCh)
+	s.wg.Wait()
+	log.Println("Snooze worker stopped")
+}
+
+func (s *SnoozeWaker) run() {
+	defer s.wg.Done()
+
+	s.wake()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.wake()
+		case <-s.stopCh:
+			return
+		}
+	}
+}
+
+func (s *SnoozeWaker) wake() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	released, err := s.repo.ReleaseDue(ctx)
+	if err != nil {
+		log.Printf("Snooze worker error: %v", err)
+		return
+	}
+
+	for userID, ids := range released {
+		if s.hub != nil {
+			s.hub.BroadcastToUser(userID, "read_later_due", map[string]interface{}{
+				"ids": ids,
+			})
+		}
+	}
+}
@@ -53,6 +53,9 @@ var upgrader = websocket.Upgrader{
 type Event struct {
 	Type    string          `json:"type"`
 	Payload json.RawMessage `json:"payload"`
+
+	// UserID restricts delivery to one user's clients (uuid.Nil = everyone).
+	UserID uuid.UUID `json:"-"`
 }
 
 // Client represents a connected user via websocket.
@@ -122,7 +125,10 @@ func (h *Hub) Run() {
 			data, _ := json.Marshal(event)
 
 			h.mu.RLock()
-			for _, userClients := range h.clients {
+			for userID, userClients := range h.clients {
+				if event.UserID != uuid.Nil && event.UserID != userID {
+					continue
+				}
 				for _, client := range userClients {
 					select {
 					case client.Send <- data:
@@ -150,6 +156,20 @@ func (h *Hub) Broadcast(eventType string, payload interface{}) {
 	}
 }
 
+// BroadcastToUser sends an event to the connected clients of a single user.
+func (h *Hub) BroadcastToUser(userID uuid.UUID, eventType string, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("Error marshaling broadcast payload: %v", err)
+		return
+	}
+	h.broadcast <- Event{
+		Type:    eventType,
+		Payload: json.RawMessage(data),
+		UserID:  userID,
+	}
+}
+
 // ServeWS handles websocket requests.
 func (h *Hub) ServeWS(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
 	conn, err := upgrader.Upgrade(w, r, nil)
@@ -0,0 +1,3 @@
+-- Rollback: 007_add_user_preferences
+
+ALTER TABLE users DROP COLUMN IF EXISTS proxy_images;
@@ -0,0 +1,4 @@
+-- Migration: 007_add_user_preferences
+-- Description: Add per-user reading preferences (image proxy opt-in)
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS proxy_images BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,3 @@
+-- Rollback: 008_create_feed_icons
+
+DROP TABLE IF EXISTS feed_icons;
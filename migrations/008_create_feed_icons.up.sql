@@ -0,0 +1,12 @@
+-- Migration: 008_create_feed_icons
+-- Description: Store resolved site favicons per feed
+
+CREATE TABLE IF NOT EXISTS feed_icons (
+    feed_id UUID PRIMARY KEY REFERENCES feeds(id) ON DELETE CASCADE,
+    -- NULL data records a failed lookup so it is not retried on every fetch
+    data BYTEA,
+    mime_type VARCHAR(100),
+    source_url VARCHAR(2048),
+    hash VARCHAR(64),
+    fetched_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
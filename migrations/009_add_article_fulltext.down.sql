@@ -0,0 +1,4 @@
+-- Rollback: 009_add_article_fulltext
+
+ALTER TABLE articles DROP COLUMN IF EXISTS full_content_fetched_at;
+ALTER TABLE articles DROP COLUMN IF EXISTS full_content;
@@ -0,0 +1,5 @@
+-- Migration: 009_add_article_fulltext
+-- Description: Cache the extracted full-page content of articles (reader view)
+
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS full_content TEXT;
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS full_content_fetched_at TIMESTAMPTZ;
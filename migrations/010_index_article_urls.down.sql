@@ -0,0 +1,3 @@
+-- Rollback: 010_index_article_urls
+
+DROP INDEX IF EXISTS idx_articles_feed_url;
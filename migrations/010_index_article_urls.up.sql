@@ -0,0 +1,4 @@
+-- Migration: 010_index_article_urls
+-- Description: Index canonical article URLs for duplicate detection at ingest
+
+CREATE INDEX IF NOT EXISTS idx_articles_feed_url ON articles(feed_id, url);
@@ -0,0 +1,4 @@
+-- Rollback: 011_add_sanitizer_version
+
+DROP INDEX IF EXISTS idx_articles_sanitizer_version;
+ALTER TABLE articles DROP COLUMN IF EXISTS sanitizer_version;
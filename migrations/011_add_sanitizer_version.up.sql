@@ -0,0 +1,6 @@
+-- Migration: 011_add_sanitizer_version
+-- Description: Track the sanitizer policy version stored article HTML was cleaned with
+
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS sanitizer_version INT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_articles_sanitizer_version ON articles(sanitizer_version);
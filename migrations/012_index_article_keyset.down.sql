@@ -0,0 +1,3 @@
+-- Rollback: 012_index_article_keyset
+
+DROP INDEX IF EXISTS idx_articles_feed_keyset;
@@ -0,0 +1,5 @@
+-- Migration: 012_index_article_keyset
+-- Description: Index the stable article ordering used by keyset pagination
+
+CREATE INDEX IF NOT EXISTS idx_articles_feed_keyset
+    ON articles(feed_id, (COALESCE(published_at, '-infinity'::timestamptz)) DESC, created_at DESC, id DESC);
@@ -0,0 +1,4 @@
+-- Rollback: 013_add_feed_category
+
+DROP INDEX IF EXISTS idx_feeds_user_category;
+ALTER TABLE feeds DROP COLUMN IF EXISTS category;
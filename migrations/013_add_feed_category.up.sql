@@ -0,0 +1,6 @@
+-- Migration: 013_add_feed_category
+-- Description: Group feeds into user-defined categories
+
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS category VARCHAR(255);
+
+CREATE INDEX IF NOT EXISTS idx_feeds_user_category ON feeds(user_id, category);
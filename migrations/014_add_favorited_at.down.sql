@@ -0,0 +1,3 @@
+-- Rollback: 014_add_favorited_at
+
+ALTER TABLE articles DROP COLUMN IF EXISTS favorited_at;
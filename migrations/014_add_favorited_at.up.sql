@@ -0,0 +1,8 @@
+-- Migration: 014_add_favorited_at
+-- Description: Track when an article was starred
+
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS favorited_at TIMESTAMPTZ;
+
+-- Best effort for existing favorites: the actual time is unknown
+UPDATE articles SET favorited_at = COALESCE(read_at, created_at)
+WHERE is_favorite = true AND favorited_at IS NULL;
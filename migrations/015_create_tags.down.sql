@@ -0,0 +1,4 @@
+-- Rollback: 015_create_tags
+
+DROP TABLE IF EXISTS article_tags;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,21 @@
+-- Migration: 015_create_tags
+-- Description: User-defined tags on articles
+
+CREATE TABLE IF NOT EXISTS tags (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(64) NOT NULL,
+    color VARCHAR(16),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_user_name ON tags(user_id, LOWER(name));
+
+CREATE TABLE IF NOT EXISTS article_tags (
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (article_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_article_tags_tag_id ON article_tags(tag_id);
@@ -0,0 +1,3 @@
+-- Rollback: 016_create_highlights
+
+DROP TABLE IF EXISTS highlights;
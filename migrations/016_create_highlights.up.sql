@@ -0,0 +1,19 @@
+-- Migration: 016_create_highlights
+-- Description: Highlights and notes on articles
+
+CREATE TABLE IF NOT EXISTS highlights (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    quote TEXT NOT NULL,
+    prefix TEXT,
+    suffix TEXT,
+    start_offset INT,
+    color VARCHAR(16) NOT NULL DEFAULT 'yellow',
+    note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_highlights_article_id ON highlights(article_id);
+CREATE INDEX IF NOT EXISTS idx_highlights_user_created ON highlights(user_id, created_at DESC);
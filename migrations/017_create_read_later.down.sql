@@ -0,0 +1,5 @@
+-- Rollback: 017_create_read_later
+
+DROP TRIGGER IF EXISTS trg_read_later_remove_on_read ON articles;
+DROP FUNCTION IF EXISTS read_later_remove_on_read();
+DROP TABLE IF EXISTS read_later;
@@ -0,0 +1,31 @@
+-- Migration: 017_create_read_later
+-- Description: Read-later queue with ordering and snooze
+
+CREATE TABLE IF NOT EXISTS read_later (
+    article_id UUID PRIMARY KEY REFERENCES articles(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    position DOUBLE PRECISION NOT NULL,
+    snoozed_until TIMESTAMPTZ,
+    added_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_read_later_user_position ON read_later(user_id, position);
+CREATE INDEX IF NOT EXISTS idx_read_later_snoozed ON read_later(snoozed_until) WHERE snoozed_until IS NOT NULL;
+
+-- Reading an article takes it out of the queue, unless it is snoozed (a
+-- snoozed article is hidden as read until it comes back)
+CREATE OR REPLACE FUNCTION read_later_remove_on_read() RETURNS trigger AS $$
+BEGIN
+    IF NEW.is_read AND NOT OLD.is_read THEN
+        DELETE FROM read_later
+        WHERE article_id = NEW.id
+          AND (snoozed_until IS NULL OR snoozed_until <= NOW());
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_read_later_remove_on_read ON articles;
+CREATE TRIGGER trg_read_later_remove_on_read
+    AFTER UPDATE OF is_read ON articles
+    FOR EACH ROW EXECUTE FUNCTION read_later_remove_on_read();
@@ -0,0 +1,3 @@
+-- Rollback: 018_create_reading_events
+
+DROP TABLE IF EXISTS reading_events;
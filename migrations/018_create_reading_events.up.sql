@@ -0,0 +1,24 @@
+-- Migration: 018_create_reading_events
+-- Description: Reading history (read and open events) for reading statistics
+
+CREATE TABLE IF NOT EXISTS reading_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- History outlives cleaned-up articles and removed feeds
+    article_id UUID REFERENCES articles(id) ON DELETE SET NULL,
+    feed_id UUID REFERENCES feeds(id) ON DELETE SET NULL,
+    event_type VARCHAR(16) NOT NULL,
+    dwell_seconds INT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_reading_events_user_created ON reading_events(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_reading_events_article_id ON reading_events(article_id);
+CREATE INDEX IF NOT EXISTS idx_reading_events_feed_id ON reading_events(feed_id);
+
+-- Seed the history with the reads recorded so far
+INSERT INTO reading_events (user_id, article_id, feed_id, event_type, created_at)
+SELECT f.user_id, a.id, a.feed_id, 'read', a.read_at
+FROM articles a
+JOIN feeds f ON f.id = a.feed_id
+WHERE a.is_read = true AND a.read_at IS NOT NULL;
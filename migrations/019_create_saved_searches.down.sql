@@ -0,0 +1,3 @@
+-- Rollback: 019_create_saved_searches
+
+DROP TABLE IF EXISTS saved_searches;
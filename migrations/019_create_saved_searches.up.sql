@@ -0,0 +1,15 @@
+-- Migration: 019_create_saved_searches
+-- Description: Saved searches (smart folders)
+
+CREATE TABLE IF NOT EXISTS saved_searches (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(128) NOT NULL,
+    query TEXT NOT NULL DEFAULT '',
+    filters JSONB NOT NULL DEFAULT '{}',
+    position INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_saved_searches_user_name ON saved_searches(user_id, LOWER(name));
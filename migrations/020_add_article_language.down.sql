@@ -0,0 +1,17 @@
+-- Rollback: 020_add_article_language
+
+DROP INDEX IF EXISTS idx_articles_language_pending;
+DROP INDEX IF EXISTS idx_articles_tsv;
+ALTER TABLE articles DROP COLUMN IF EXISTS tsv;
+ALTER TABLE articles
+ADD COLUMN tsv tsvector
+GENERATED ALWAYS AS (
+    setweight(to_tsvector('french', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('french', coalesce(summary, '')), 'B') ||
+    setweight(to_tsvector('french', coalesce(content, '')), 'C')
+) STORED;
+CREATE INDEX IF NOT EXISTS idx_articles_tsv ON articles USING GIN(tsv);
+
+ALTER TABLE feeds DROP COLUMN IF EXISTS languages;
+ALTER TABLE articles DROP COLUMN IF EXISTS language;
+DROP FUNCTION IF EXISTS article_ts_config(TEXT);
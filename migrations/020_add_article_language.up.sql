@@ -0,0 +1,49 @@
+-- Migration: 020_add_article_language
+-- Description: Per-article language and language-aware full-text index
+
+-- Text search configuration for a language code; mirrors
+-- utils.TextSearchConfig. Unknown and not yet detected languages (NULL) are
+-- indexed without stemming.
+CREATE OR REPLACE FUNCTION article_ts_config(lang TEXT) RETURNS regconfig AS $$
+    SELECT CASE lang
+        WHEN 'da' THEN 'danish'
+        WHEN 'de' THEN 'german'
+        WHEN 'en' THEN 'english'
+        WHEN 'es' THEN 'spanish'
+        WHEN 'fi' THEN 'finnish'
+        WHEN 'fr' THEN 'french'
+        WHEN 'hu' THEN 'hungarian'
+        WHEN 'it' THEN 'italian'
+        WHEN 'nb' THEN 'norwegian'
+        WHEN 'nl' THEN 'dutch'
+        WHEN 'nn' THEN 'norwegian'
+        WHEN 'no' THEN 'norwegian'
+        WHEN 'pt' THEN 'portuguese'
+        WHEN 'ro' THEN 'romanian'
+        WHEN 'ru' THEN 'russian'
+        WHEN 'sv' THEN 'swedish'
+        WHEN 'tr' THEN 'turkish'
+        ELSE 'simple'
+    END::regconfig
+$$ LANGUAGE SQL IMMUTABLE PARALLEL SAFE;
+
+-- NULL until detected; the language backfill worker fills existing rows
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS language VARCHAR(8);
+
+-- Languages seen in each feed, so that searches can expand the query over
+-- the languages a user actually reads
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS languages TEXT[] NOT NULL DEFAULT '{}';
+
+-- Rebuild the search vector with the article's own configuration
+DROP INDEX IF EXISTS idx_articles_tsv;
+ALTER TABLE articles DROP COLUMN IF EXISTS tsv;
+ALTER TABLE articles
+ADD COLUMN tsv tsvector
+GENERATED ALWAYS AS (
+    setweight(to_tsvector(article_ts_config(language), coalesce(title, '')), 'A') ||
+    setweight(to_tsvector(article_ts_config(language), coalesce(summary, '')), 'B') ||
+    setweight(to_tsvector(article_ts_config(language), coalesce(content, '')), 'C')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_articles_tsv ON articles USING GIN(tsv);
+CREATE INDEX IF NOT EXISTS idx_articles_language_pending ON articles(created_at) WHERE language IS NULL;
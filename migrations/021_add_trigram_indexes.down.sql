@@ -0,0 +1,7 @@
+-- Rollback: 021_add_trigram_indexes
+
+DROP INDEX IF EXISTS idx_feeds_url_trgm;
+DROP INDEX IF EXISTS idx_feeds_title_trgm;
+DROP INDEX IF EXISTS idx_articles_title_trgm;
+
+-- pg_trgm is left installed: other database objects may depend on it
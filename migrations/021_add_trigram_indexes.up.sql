@@ -0,0 +1,8 @@
+-- Migration: 021_add_trigram_indexes
+-- Description: Trigram indexes for fuzzy title search and the quick switcher
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_articles_title_trgm ON articles USING GIN(title gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_feeds_title_trgm ON feeds USING GIN(title gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_feeds_url_trgm ON feeds USING GIN(url gin_trgm_ops);
@@ -0,0 +1,3 @@
+-- Rollback: 022_create_retention_policies
+
+DROP TABLE IF EXISTS retention_policies;
@@ -0,0 +1,31 @@
+-- Migration: 022_create_retention_policies
+-- Description: Article retention policies at instance, user and feed level
+
+-- A feed's articles follow the feed policy, else its owner's user policy,
+-- else the instance policy. A policy without max_age_days and max_articles
+-- never deletes anything.
+CREATE TABLE IF NOT EXISTS retention_policies (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    scope VARCHAR(16) NOT NULL CHECK (scope IN ('instance', 'user', 'feed')),
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    feed_id UUID REFERENCES feeds(id) ON DELETE CASCADE,
+    max_age_days INTEGER CHECK (max_age_days > 0),
+    max_articles INTEGER CHECK (max_articles > 0),
+    keep_unread BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (
+        (scope = 'instance' AND user_id IS NULL AND feed_id IS NULL) OR
+        (scope = 'user' AND user_id IS NOT NULL AND feed_id IS NULL) OR
+        (scope = 'feed' AND user_id IS NOT NULL AND feed_id IS NOT NULL)
+    )
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_retention_policies_instance ON retention_policies(scope) WHERE scope = 'instance';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_retention_policies_user ON retention_policies(user_id) WHERE scope = 'user';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_retention_policies_feed ON retention_policies(feed_id) WHERE scope = 'feed';
+
+-- The instance default keeps the previous hard-coded behavior: 30 days
+INSERT INTO retention_policies (scope, max_age_days)
+SELECT 'instance', 30
+WHERE NOT EXISTS (SELECT 1 FROM retention_policies WHERE scope = 'instance');
@@ -0,0 +1,3 @@
+-- Rollback: 023_create_article_archives
+
+DROP TABLE IF EXISTS article_archives;
@@ -0,0 +1,19 @@
+-- Migration: 023_create_article_archives
+-- Description: Self-contained archive snapshots of articles
+
+CREATE TABLE IF NOT EXISTS article_archives (
+    article_id UUID PRIMARY KEY REFERENCES articles(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    url TEXT,
+    author TEXT,
+    published_at TIMESTAMPTZ,
+    content TEXT NOT NULL,
+    source VARCHAR(16) NOT NULL CHECK (source IN ('extracted', 'feed')),
+    image_count INTEGER NOT NULL DEFAULT 0,
+    failed_image_count INTEGER NOT NULL DEFAULT 0,
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_article_archives_user ON article_archives(user_id, archived_at DESC);
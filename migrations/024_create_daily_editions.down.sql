@@ -0,0 +1,3 @@
+-- Rollback: 024_create_daily_editions
+
+DROP TABLE IF EXISTS daily_editions;
@@ -0,0 +1,23 @@
+-- Migration: 024_create_daily_editions
+-- Description: Scheduled daily EPUB editions, one download slot per user
+
+CREATE TABLE IF NOT EXISTS daily_editions (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    source VARCHAR(16) NOT NULL DEFAULT 'unread' CHECK (source IN ('unread', 'saved_search', 'read_later')),
+    category VARCHAR(255),
+    saved_search_id UUID REFERENCES saved_searches(id) ON DELETE SET NULL,
+    max_articles INTEGER NOT NULL DEFAULT 50 CHECK (max_articles BETWEEN 1 AND 200),
+    hour SMALLINT NOT NULL DEFAULT 6 CHECK (hour BETWEEN 0 AND 23),
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    epub BYTEA,
+    article_count INTEGER NOT NULL DEFAULT 0,
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    generated_at TIMESTAMPTZ,
+    attempted_at TIMESTAMPTZ,
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_daily_editions_enabled ON daily_editions(user_id) WHERE enabled;
@@ -0,0 +1,6 @@
+-- Rollback: 025_add_article_fulltext_metadata
+
+ALTER TABLE articles DROP COLUMN IF EXISTS full_content_lead_image;
+ALTER TABLE articles DROP COLUMN IF EXISTS full_content_site_name;
+ALTER TABLE articles DROP COLUMN IF EXISTS full_content_byline;
+ALTER TABLE articles DROP COLUMN IF EXISTS full_content_title;
@@ -0,0 +1,7 @@
+-- Migration: 025_add_article_fulltext_metadata
+-- Description: Cache the structured reader view output (title, byline, site name, lead image)
+
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS full_content_title TEXT;
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS full_content_byline TEXT;
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS full_content_site_name TEXT;
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS full_content_lead_image TEXT;
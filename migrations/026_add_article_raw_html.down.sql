@@ -0,0 +1,5 @@
+-- Rollback: 026_add_article_raw_html
+
+ALTER TABLE articles DROP COLUMN IF EXISTS full_content_raw;
+ALTER TABLE articles DROP COLUMN IF EXISTS summary_raw;
+ALTER TABLE articles DROP COLUMN IF EXISTS content_raw;
@@ -0,0 +1,6 @@
+-- Migration: 026_add_article_raw_html
+-- Description: Keep the HTML as received so policy changes re-sanitize from the source
+
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS content_raw TEXT;
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS summary_raw TEXT;
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS full_content_raw TEXT;